@@ -13,16 +13,26 @@ var backupCmd = &cobra.Command{
 }
 
 var backupCreateCmd = &cobra.Command{
-    Use:   "create [volume-name]",
+    Use:   "create [volume-name | pvc/<namespace>/<name>]",
     Short: "Create a backup",
     Long:  `Create a backup of the specified volume.`,
-    Args:  cobra.ExactArgs(1),
-    Run: func(cmd *cobra.Command, args []string) {
-        volumeName := args[0]
+    Args:  volumeArgs,
+    RunE: func(cmd *cobra.Command, args []string) error {
+        ctx := cmd.Context()
+
+        ref, err := volumeArgRef(cmd, args)
+        if err != nil {
+            return err
+        }
+        volumeName, err := resolveVolumeName(ctx, nil, ref)
+        if err != nil {
+            return err
+        }
         snapshot, _ := cmd.Flags().GetString("snapshot")
         
         fmt.Printf("Creating backup for volume %s from snapshot %s...\n", volumeName, snapshot)
         // TODO: Implement backup create logic
+        return nil
     },
 }
 
@@ -30,10 +40,21 @@ var backupListCmd = &cobra.Command{
     Use:   "list",
     Short: "List backups",
     Long:  `List all backups or backups for a specific volume.`,
-    Run: func(cmd *cobra.Command, args []string) {
-        volume, _ := cmd.Flags().GetString("volume")
+    RunE: func(cmd *cobra.Command, args []string) error {
+        ctx := cmd.Context()
+
+        volumeFlag, _ := cmd.Flags().GetString("volume")
+        ref, err := volumeRef(cmd, volumeFlag)
+        if err != nil {
+            return err
+        }
+        volume, err := resolveVolumeName(ctx, nil, ref)
+        if err != nil {
+            return err
+        }
         fmt.Printf("Listing backups (volume: %s)...\n", volume)
         // TODO: Implement backup list logic
+        return nil
     },
 }
 
@@ -45,7 +66,9 @@ func init() {
     // Backup create flags
     backupCreateCmd.Flags().String("snapshot", "", "Snapshot to backup from")
     backupCreateCmd.Flags().StringToString("labels", nil, "Labels for the backup")
+    addPVCFlag(backupCreateCmd)
     
     // Backup list flags
-    backupListCmd.Flags().String("volume", "", "Filter by volume name")
+    backupListCmd.Flags().String("volume", "", "Filter by volume name or pvc/<namespace>/<name>")
+    addPVCFlag(backupListCmd)
 }
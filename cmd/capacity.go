@@ -0,0 +1,163 @@
+// cmd/capacity.go
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/capacity"
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/utils"
+)
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Show cluster storage capacity",
+	Long: `Show storage capacity per disk, node, zone, disk tag and for the whole cluster.
+
+Scheduled storage is compared against the storage-over-provisioning-percentage
+and storage-minimal-available-percentage settings, and disks that cannot accept
+new replicas are highlighted together with the reason.`,
+	RunE: runCapacity,
+}
+
+func init() {
+	rootCmd.AddCommand(capacityCmd)
+}
+
+func runCapacity(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := c.Nodes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	report := capacity.BuildReport(nodes, getSchedulingSettings(ctx, c))
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(report)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(report)
+	default:
+		return printCapacityReport(report)
+	}
+}
+
+// getSchedulingSettings reads the scheduler thresholds, falling back to the
+// Longhorn defaults if the settings cannot be read
+func getSchedulingSettings(ctx gocontext.Context, c *client.Client) capacity.Settings {
+	settings, err := c.Settings().List(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to read settings, using defaults: %v\n", err)
+		return capacity.DefaultSettings()
+	}
+	return capacity.SettingsFromMap(settings)
+}
+
+func printCapacityReport(report *capacity.Report) error {
+	fmt.Printf("Over-provisioning: %d%%, minimal available: %d%%\n\n",
+		report.Settings.OverProvisioningPercentage,
+		report.Settings.MinimalAvailablePercentage)
+
+	fmt.Println("Disks:")
+	headers := []string{
+		"NODE",
+		"DISK",
+		"PATH",
+		"TAGS",
+		"MAXIMUM",
+		"RESERVED",
+		"AVAILABLE",
+		"SCHEDULED",
+		"LIMIT",
+		"SCHED %",
+		"SCHEDULABLE",
+	}
+	table := formatter.NewTableFormatter(headers)
+	for _, d := range report.Disks {
+		schedulable := color.GreenString("yes")
+		if !d.Schedulable {
+			schedulable = color.RedString("no (%s)", d.Reason)
+		}
+		tags := strings.Join(d.Tags, ",")
+		if tags == "" {
+			tags = capacity.NoneLabel
+		}
+		table.AddRow([]string{
+			d.Node,
+			shortenDiskID(d.DiskID),
+			d.Path,
+			tags,
+			utils.FormatSize(d.Maximum),
+			utils.FormatSize(d.Reserved),
+			utils.FormatSize(d.Available),
+			utils.FormatSize(d.Scheduled),
+			utils.FormatSize(d.Limit),
+			formatter.FormatPercent(float64(d.Scheduled), float64(d.Maximum-d.Reserved)),
+			schedulable,
+		})
+	}
+	if err := table.Format(nil); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		title     string
+		summaries []capacity.Summary
+	}{
+		{"Nodes", report.Nodes},
+		{"Zones", report.Zones},
+		{"Disk Tags", report.Tags},
+		{"Cluster", []capacity.Summary{report.Cluster}},
+	}
+	for _, section := range sections {
+		fmt.Printf("\n%s:\n", section.title)
+		if err := printCapacitySummaries(section.summaries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printCapacitySummaries(summaries []capacity.Summary) error {
+	headers := []string{
+		"NAME",
+		"SCHED DISKS",
+		"MAXIMUM",
+		"RESERVED",
+		"AVAILABLE",
+		"USED %",
+		"SCHEDULED",
+		"LIMIT",
+		"SCHED %",
+	}
+	table := formatter.NewTableFormatter(headers)
+	for _, s := range summaries {
+		table.AddRow([]string{
+			s.Name,
+			fmt.Sprintf("%d/%d", s.SchedulableDisks, s.Disks),
+			utils.FormatSize(s.Maximum),
+			utils.FormatSize(s.Reserved),
+			utils.FormatSize(s.Available),
+			formatter.FormatPercent(float64(s.Maximum-s.Available), float64(s.Maximum)),
+			utils.FormatSize(s.Scheduled),
+			utils.FormatSize(s.Limit),
+			formatter.FormatPercent(float64(s.Scheduled), float64(s.Maximum-s.Reserved)),
+		})
+	}
+	return table.Format(nil)
+}
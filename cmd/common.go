@@ -1,15 +1,48 @@
 package cmd
 
 import (
+	gocontext "context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/bundle"
 	"github.com/pascal71/lhcli/pkg/client"
 	"github.com/pascal71/lhcli/pkg/config"
 )
 
 // getClient creates a client based on the current configuration
 func getClient() (*client.Client, error) {
+	if conflictRetries < 0 {
+		return nil, fmt.Errorf("--conflict-retries must not be negative")
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetConflictRetries(conflictRetries)
+	if verbose {
+		c.SetRetryLogger(func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		})
+	}
+
+	return c, nil
+}
+
+// newClient creates the client for the configured context or bundle
+func newClient() (*client.Client, error) {
+	// Support bundles are served by a read-only client
+	if fromBundle != "" {
+		return bundle.NewClient(fromBundle)
+	}
+
 	// Load configuration
 	cfg, err := config.Load(cfgFile)
 	if err != nil {
@@ -34,6 +67,20 @@ func getClient() (*client.Client, error) {
 		}
 	}
 
+	// Contexts can point at a support bundle with a file:// endpoint
+	if bundlePath, ok := strings.CutPrefix(ctx.Endpoint, "file://"); ok {
+		return bundle.NewClient(bundlePath)
+	}
+
+	timeout, err := requestTimeout(cfg)
+	if err != nil {
+		return nil, err
+	}
+	retry, err := retryPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check auth type
 	switch ctx.Auth.Type {
 	case "kubeconfig":
@@ -42,6 +89,8 @@ func getClient() (*client.Client, error) {
 			ConfigPath: ctx.Auth.Path,
 			Context:    ctx.Auth.Context,
 			Namespace:  ns,
+			Timeout:    timeout,
+			Retry:      retry,
 		}
 		return client.NewClientFromKubeconfig(kubeConfig)
 
@@ -51,7 +100,8 @@ func getClient() (*client.Client, error) {
 			Endpoint:  ctx.Endpoint,
 			Namespace: ns,
 			Token:     ctx.Auth.Token,
-			Timeout:   30 * time.Second,
+			Timeout:   timeout,
+			Retry:     retry,
 		}
 		return client.NewClient(clientConfig)
 
@@ -60,7 +110,8 @@ func getClient() (*client.Client, error) {
 		clientConfig := &client.Config{
 			Endpoint:  ctx.Endpoint,
 			Namespace: ns,
-			Timeout:   30 * time.Second,
+			Timeout:   timeout,
+			Retry:     retry,
 		}
 		return client.NewClient(clientConfig)
 
@@ -68,3 +119,194 @@ func getClient() (*client.Client, error) {
 		return nil, fmt.Errorf("unsupported auth type: %s", ctx.Auth.Type)
 	}
 }
+
+// defaultRequestTimeout is used when the config file sets no timeout
+const defaultRequestTimeout = 30 * time.Second
+
+// requestTimeout returns the timeout of each API request: defaults.timeout
+// from the config file. --timeout bounds the whole command instead.
+func requestTimeout(cfg *config.Config) (time.Duration, error) {
+	if cfg.Defaults.Timeout == "" {
+		return defaultRequestTimeout, nil
+	}
+	configured, err := time.ParseDuration(cfg.Defaults.Timeout)
+	if err != nil || configured < 0 {
+		return 0, fmt.Errorf("invalid defaults.timeout %q in config file", cfg.Defaults.Timeout)
+	}
+	return configured, nil
+}
+
+// retryPolicy returns the retry policy of a context, based on the default
+// policy
+func retryPolicy(ctx *config.Context) (*client.RetryPolicy, error) {
+	policy := client.DefaultRetryPolicy
+	if ctx.Retry == nil {
+		return &policy, nil
+	}
+
+	if ctx.Retry.MaxRetries != nil {
+		if *ctx.Retry.MaxRetries < 0 {
+			return nil, fmt.Errorf("invalid retry.max-retries %d in context %s",
+				*ctx.Retry.MaxRetries, ctx.Name)
+		}
+		policy.MaxRetries = *ctx.Retry.MaxRetries
+	}
+
+	durations := []struct {
+		key   string
+		value string
+		dest  *time.Duration
+	}{
+		{"initial-backoff", ctx.Retry.InitialBackoff, &policy.InitialBackoff},
+		{"max-backoff", ctx.Retry.MaxBackoff, &policy.MaxBackoff},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid retry.%s %q in context %s", d.key, d.value, ctx.Name)
+		}
+		*d.dest = parsed
+	}
+
+	return &policy, nil
+}
+
+// pvcRefPrefix marks a volume reference that names a PVC instead of a
+// Longhorn volume, e.g. pvc/shop/data-web-0
+const pvcRefPrefix = "pvc/"
+
+// addPVCFlag adds the --pvc flag to a command that selects a volume
+func addPVCFlag(cmd *cobra.Command) {
+	cmd.Flags().String("pvc", "", "Select the volume bound to this PVC (<namespace>/<name>)")
+}
+
+// volumeArgs requires a volume argument unless --pvc is given
+func volumeArgs(cmd *cobra.Command, args []string) error {
+	if pvc, _ := cmd.Flags().GetString("pvc"); pvc != "" {
+		return cobra.MaximumNArgs(1)(cmd, args)
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// volumeCreateArgs requires the name of the new volume, which --simulate does
+// not need
+func volumeCreateArgs(cmd *cobra.Command, args []string) error {
+	if simulate, _ := cmd.Flags().GetBool("simulate"); simulate {
+		return cobra.MaximumNArgs(1)(cmd, args)
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// volumeArgRef returns the volume reference given as argument or with --pvc
+func volumeArgRef(cmd *cobra.Command, args []string) (string, error) {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	return volumeRef(cmd, name)
+}
+
+// volumeRef returns the volume reference of a command: the given name, or a
+// PVC reference if --pvc is set. Giving both is an error.
+func volumeRef(cmd *cobra.Command, name string) (string, error) {
+	pvc, _ := cmd.Flags().GetString("pvc")
+	if pvc == "" {
+		return name, nil
+	}
+	if name != "" {
+		return "", fmt.Errorf("specify either a volume or --pvc, not both")
+	}
+	return pvcRefPrefix + pvc, nil
+}
+
+// resolveVolumeName returns the Longhorn volume name of a volume reference.
+// Plain names are returned unchanged and pvc/<namespace>/<name> is resolved
+// to the volume bound to the PVC. A nil client is created on demand, so
+// commands that do not need one otherwise only connect for PVC references.
+func resolveVolumeName(ctx gocontext.Context, c *client.Client, ref string) (string, error) {
+	pvc, ok := strings.CutPrefix(ref, pvcRefPrefix)
+	if !ok {
+		return ref, nil
+	}
+
+	pvcNamespace, pvcName, ok := strings.Cut(pvc, "/")
+	if !ok || pvcNamespace == "" || pvcName == "" || strings.Contains(pvcName, "/") {
+		return "", fmt.Errorf("invalid PVC reference %q, expected pvc/<namespace>/<name>", ref)
+	}
+
+	if c == nil {
+		var err error
+		if c, err = getClient(); err != nil {
+			return "", err
+		}
+	}
+
+	return c.ResolvePVC(ctx, pvcNamespace, pvcName)
+}
+
+// exitError is returned by commands that need a specific process exit code.
+// The command has already printed its own output, so cobra stays silent.
+type exitError struct {
+	code int
+	msg  string
+}
+
+func (e *exitError) Error() string {
+	return e.msg
+}
+
+// apiErrorExits are the exit codes and hints for the kinds of API errors, so
+// scripts can tell why a command failed without parsing its output
+var apiErrorExits = []struct {
+	kind error
+	code int
+	hint string
+}{
+	{client.ErrNotFound, 3, "check the name, and the namespace with --namespace"},
+	{client.ErrAlreadyExists, 4, "choose another name or delete the existing resource first"},
+	{client.ErrConflict, 5, "the resource was changed by someone else; run the command again"},
+	{client.ErrForbidden, 6, "check the permissions of your kubeconfig user or API token"},
+	{client.ErrUnavailable, 7, "check that the cluster and the Longhorn manager are reachable"},
+}
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	kind := client.KindOf(err)
+	for _, exit := range apiErrorExits {
+		if exit.kind == kind {
+			return exit.code
+		}
+	}
+	return 1
+}
+
+// errorHint returns advice for an error returned by Execute, if any
+func errorHint(err error) string {
+	kind := client.KindOf(err)
+	if kind == nil {
+		return ""
+	}
+	for _, exit := range apiErrorExits {
+		if exit.kind == kind {
+			return exit.hint
+		}
+	}
+	return ""
+}
+
+// silentExit returns an exitError and stops cobra from printing the error and usage
+func silentExit(cmd *cobra.Command, code int, msg string) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &exitError{code: code, msg: msg}
+}
@@ -0,0 +1,103 @@
+// cmd/common_test.go
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+func TestExitCode(t *testing.T) {
+	nodes := schema.GroupResource{Group: "longhorn.io", Resource: "nodes"}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: 0},
+		{name: "other error", err: errors.New("boom"), want: 1},
+		{name: "check failed", err: silentExit(&cobra.Command{}, 2, "NO-GO"), want: 2},
+		{
+			name: "not found",
+			err:  fmt.Errorf("failed to get node: %w", apierrors.NewNotFound(nodes, "node-1")),
+			want: 3,
+		},
+		{
+			name: "already exists",
+			err:  &client.ErrorResponse{Status: http.StatusConflict, Code: "AlreadyExists"},
+			want: 4,
+		},
+		{
+			name: "conflict",
+			err: fmt.Errorf("failed to update node node-1: %w",
+				apierrors.NewConflict(nodes, "node-1", errors.New("modified"))),
+			want: 5,
+		},
+		{
+			name: "forbidden",
+			err:  fmt.Errorf("failed to list: %w", &client.ErrorResponse{Status: http.StatusForbidden}),
+			want: 6,
+		},
+		{
+			name: "unavailable",
+			err:  apierrors.NewServiceUnavailable("restarting"),
+			want: 7,
+		},
+		{
+			name: "exit code wins over kind",
+			err: fmt.Errorf("%w: %w", silentExit(&cobra.Command{}, 2, "NO-GO"),
+				client.ErrNotFound),
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVolumeCreateArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		simulate bool
+		wantErr  bool
+	}{
+		{name: "name", args: []string{"vol-1"}},
+		{name: "no name", wantErr: true},
+		{name: "two names", args: []string{"vol-1", "vol-2"}, wantErr: true},
+		{name: "simulate without name", simulate: true},
+		{name: "simulate with name", args: []string{"vol-1"}, simulate: true},
+		{
+			name:     "simulate with two names",
+			args:     []string{"vol-1", "vol-2"},
+			simulate: true,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("simulate", tt.simulate, "")
+
+			err := volumeCreateArgs(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("volumeCreateArgs(%q) error = %v, want error %v",
+					tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,506 @@
+// cmd/dashboard.go
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Interactive full-screen cluster dashboard",
+	Long: `Show a full-screen dashboard of the cluster with panes for volumes (faulted
+and degraded first), nodes and disks with capacity bars, in-progress rebuilds
+and recent events.
+
+Keys:
+  tab / shift-tab   switch pane
+  up/down, k/j      move the selection
+  enter             show the replicas of the selected volume
+  esc               go back to the overview
+  d                 detach the selected volume
+  e                 disable scheduling on the selected node or disk and evict it
+  x                 delete the selected replica (replica view)
+  r                 refresh now
+  q, ctrl-c         quit
+
+Every action asks for confirmation before it is executed.`,
+	RunE: runDashboard,
+}
+
+func init() {
+	rootCmd.AddCommand(dashboardCmd)
+
+	// Dashboard flags
+	dashboardCmd.Flags().Duration("interval", 5*time.Second, "Refresh interval")
+}
+
+// Dashboard panes, in tab order
+const (
+	paneVolumes = iota
+	paneNodes
+	paneRebuilds
+	paneEvents
+	paneCount
+)
+
+// dashboardData is a snapshot of the cluster state shown by the dashboard
+type dashboardData struct {
+	volumes    []client.Volume
+	nodes      []client.Node
+	engines    []client.Engine
+	events     []client.Event
+	volumesErr error
+	nodesErr   error
+	enginesErr error
+	eventsErr  error
+	updated    time.Time
+}
+
+// dashboardAction is an action waiting for confirmation
+type dashboardAction struct {
+	prompt string
+	done   string
+	run    func(ctx gocontext.Context) error
+}
+
+// actionResult is the outcome of an action that ran in the background
+type actionResult struct {
+	action *dashboardAction
+	err    error
+}
+
+// nodeRow is a line of the nodes pane, either a node or one of its disks
+type nodeRow struct {
+	node   client.Node
+	diskID string
+}
+
+// rebuildRow is a replica that is being rebuilt
+type rebuildRow struct {
+	volume  string
+	replica string
+	status  client.RebuildStatus
+}
+
+type dashboard struct {
+	client       *client.Client
+	out          io.Writer
+	data         dashboardData
+	pane         int
+	cursor       [paneCount]int
+	detail       string // volume whose replicas are shown
+	detailCursor int
+	pending      *dashboardAction
+	message      string
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return fmt.Errorf("dashboard requires an interactive terminal")
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("failed to configure terminal: %w", err)
+	}
+	defer term.Restore(inFd, state)
+
+	// Switch to the alternate screen and hide the cursor
+	fmt.Print("\033[?1049h\033[?25l")
+	defer fmt.Print("\033[?25h\033[?1049l")
+
+	d := &dashboard{client: c, out: os.Stdout}
+
+	keys := make(chan string)
+	go readDashboardKeys(os.Stdin, keys)
+
+	updates := make(chan dashboardData, 1)
+	fetching := false
+	refresh := func() {
+		if fetching {
+			return
+		}
+		fetching = true
+		go func() { updates <- fetchDashboardData(ctx, c) }()
+	}
+	refresh()
+
+	// Actions run in the background so that slow API calls do not freeze
+	// the screen
+	results := make(chan actionResult)
+	run := func(action *dashboardAction) {
+		d.message = strings.TrimSuffix(action.prompt, "?") + "..."
+		go func() { results <- actionResult{action: action, err: action.run(ctx)} }()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.draw(outFd)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case data := <-updates:
+			fetching = false
+			d.data = data
+			d.clampCursors()
+		case <-ticker.C:
+			refresh()
+		case result := <-results:
+			if result.err != nil {
+				d.message = fmt.Sprintf("Error: %v", result.err)
+				break
+			}
+			d.message = "✓ " + result.action.done
+			refresh()
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			quit, changed, action := d.handleKey(key)
+			if quit {
+				return nil
+			}
+			if action != nil {
+				run(action)
+			}
+			if changed {
+				refresh()
+			}
+		}
+	}
+}
+
+// fetchDashboardData reads everything shown on the dashboard. Failures are
+// recorded per pane so that one unavailable resource does not blank the screen.
+func fetchDashboardData(ctx gocontext.Context, c *client.Client) dashboardData {
+	data := dashboardData{updated: time.Now()}
+
+	data.volumes, data.volumesErr = c.Volumes().List(ctx)
+	data.nodes, data.nodesErr = c.Nodes().List(ctx)
+	data.engines, data.enginesErr = c.Engines().List(ctx)
+	data.events, data.eventsErr = c.Events().List(ctx, client.EventListOptions{})
+
+	sort.Slice(data.volumes, func(i, j int) bool {
+		ri, rj := robustnessRank(data.volumes[i]), robustnessRank(data.volumes[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return data.volumes[i].Name < data.volumes[j].Name
+	})
+	sort.Slice(data.nodes, func(i, j int) bool {
+		return data.nodes[i].Name < data.nodes[j].Name
+	})
+	sort.Slice(data.events, func(i, j int) bool {
+		return data.events[i].LastTimestamp.After(data.events[j].LastTimestamp)
+	})
+
+	return data
+}
+
+// robustnessRank orders faulted volumes first, then degraded ones
+func robustnessRank(volume client.Volume) int {
+	switch strings.ToLower(volume.Robustness) {
+	case "faulted":
+		return 0
+	case "degraded":
+		return 1
+	case "healthy":
+		return 3
+	default:
+		return 2
+	}
+}
+
+// readDashboardKeys decodes terminal input into key names
+func readDashboardKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range parseDashboardKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// parseDashboardKeys turns raw terminal input into key names such as "up",
+// "tab" or single characters
+func parseDashboardKeys(input []byte) []string {
+	var keys []string
+	for i := 0; i < len(input); i++ {
+		switch b := input[i]; {
+		case b == 0x1b && i+2 < len(input) && input[i+1] == '[':
+			switch input[i+2] {
+			case 'A':
+				keys = append(keys, "up")
+			case 'B':
+				keys = append(keys, "down")
+			case 'Z':
+				keys = append(keys, "backtab")
+			}
+			i += 2
+		case b == 0x1b:
+			keys = append(keys, "esc")
+		case b == 0x03:
+			keys = append(keys, "ctrl-c")
+		case b == '\t':
+			keys = append(keys, "tab")
+		case b == '\r' || b == '\n':
+			keys = append(keys, "enter")
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, "backspace")
+		default:
+			keys = append(keys, string(rune(b)))
+		}
+	}
+	return keys
+}
+
+// handleKey applies a key press. It reports whether the dashboard should quit,
+// whether the data should be refreshed and returns an action the user
+// confirmed, which the caller runs.
+func (d *dashboard) handleKey(key string) (quit bool, refresh bool, confirmed *dashboardAction) {
+	if d.pending != nil {
+		action := d.pending
+		d.pending = nil
+		if key != "y" && key != "Y" {
+			d.message = "Cancelled"
+			return false, false, nil
+		}
+		return false, false, action
+	}
+
+	d.message = ""
+
+	switch key {
+	case "q", "ctrl-c":
+		return true, false, nil
+	case "r":
+		return false, true, nil
+	case "esc", "backspace":
+		d.detail = ""
+	case "tab":
+		if d.detail == "" {
+			d.pane = (d.pane + 1) % paneCount
+		}
+	case "backtab":
+		if d.detail == "" {
+			d.pane = (d.pane + paneCount - 1) % paneCount
+		}
+	case "up", "k":
+		d.moveCursor(-1)
+	case "down", "j":
+		d.moveCursor(1)
+	case "enter":
+		if d.detail == "" && d.pane == paneVolumes {
+			if volume := d.selectedVolume(); volume != nil {
+				d.detail = volume.Name
+				d.detailCursor = 0
+			}
+		}
+	case "d":
+		d.confirmDetach()
+	case "e":
+		d.confirmEvict()
+	case "x":
+		d.confirmDeleteReplica()
+	}
+	return false, false, nil
+}
+
+func (d *dashboard) moveCursor(delta int) {
+	if d.detail != "" {
+		d.detailCursor += delta
+	} else {
+		d.cursor[d.pane] += delta
+	}
+	d.clampCursors()
+}
+
+// clampCursors keeps the selections within the current data
+func (d *dashboard) clampCursors() {
+	counts := [paneCount]int{
+		len(d.data.volumes),
+		len(d.nodeRows()),
+		len(d.rebuildRows()),
+		len(d.data.events),
+	}
+	for i := range d.cursor {
+		d.cursor[i] = clamp(d.cursor[i], counts[i])
+	}
+
+	if d.detail != "" {
+		volume := d.detailVolume()
+		if volume == nil {
+			d.detail = ""
+			d.message = "Volume no longer exists"
+			return
+		}
+		d.detailCursor = clamp(d.detailCursor, len(volume.Replicas))
+	}
+}
+
+func clamp(cursor, count int) int {
+	if cursor >= count {
+		cursor = count - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
+
+func (d *dashboard) selectedVolume() *client.Volume {
+	if d.detail != "" {
+		return d.detailVolume()
+	}
+	if d.pane != paneVolumes || len(d.data.volumes) == 0 {
+		return nil
+	}
+	return &d.data.volumes[d.cursor[paneVolumes]]
+}
+
+func (d *dashboard) detailVolume() *client.Volume {
+	for i := range d.data.volumes {
+		if d.data.volumes[i].Name == d.detail {
+			return &d.data.volumes[i]
+		}
+	}
+	return nil
+}
+
+// nodeRows lists every node followed by its disks
+func (d *dashboard) nodeRows() []nodeRow {
+	var rows []nodeRow
+	for _, node := range d.data.nodes {
+		rows = append(rows, nodeRow{node: node})
+
+		diskIDs := make([]string, 0, len(node.Disks))
+		for diskID := range node.Disks {
+			diskIDs = append(diskIDs, diskID)
+		}
+		sort.Strings(diskIDs)
+		for _, diskID := range diskIDs {
+			rows = append(rows, nodeRow{node: node, diskID: diskID})
+		}
+	}
+	return rows
+}
+
+// rebuildRows lists the replicas that engines are currently rebuilding
+func (d *dashboard) rebuildRows() []rebuildRow {
+	var rows []rebuildRow
+	for _, engine := range d.data.engines {
+		for replica, status := range engine.RebuildStatus {
+			if status.IsRebuilding {
+				rows = append(rows, rebuildRow{
+					volume:  engine.VolumeName,
+					replica: replica,
+					status:  status,
+				})
+			}
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].volume != rows[j].volume {
+			return rows[i].volume < rows[j].volume
+		}
+		return rows[i].replica < rows[j].replica
+	})
+	return rows
+}
+
+func (d *dashboard) confirmDetach() {
+	volume := d.selectedVolume()
+	if volume == nil {
+		d.message = "Select a volume to detach"
+		return
+	}
+
+	name := volume.Name
+	d.pending = &dashboardAction{
+		prompt: fmt.Sprintf("Detach volume %s?", name),
+		done:   fmt.Sprintf("Volume %s detach requested", name),
+		run: func(ctx gocontext.Context) error {
+			return d.client.Volumes().Detach(ctx, name)
+		},
+	}
+}
+
+func (d *dashboard) confirmEvict() {
+	rows := d.nodeRows()
+	if d.detail != "" || d.pane != paneNodes || len(rows) == 0 {
+		d.message = "Select a node or disk to evict"
+		return
+	}
+
+	row := rows[d.cursor[paneNodes]]
+	nodeName, diskID := row.node.Name, row.diskID
+	if diskID == "" {
+		d.pending = &dashboardAction{
+			prompt: fmt.Sprintf("Evict all replicas from node %s?", nodeName),
+			done:   fmt.Sprintf("Scheduling disabled and eviction requested for node %s", nodeName),
+			run: func(ctx gocontext.Context) error {
+				return drainNode(ctx, d.client.Nodes(), nodeName)
+			},
+		}
+		return
+	}
+
+	d.pending = &dashboardAction{
+		prompt: fmt.Sprintf("Evict all replicas from disk %s on node %s?", diskID, nodeName),
+		done: fmt.Sprintf("Scheduling disabled and eviction requested for disk %s on node %s",
+			diskID, nodeName),
+		run: func(ctx gocontext.Context) error {
+			return drainDisk(ctx, d.client.Nodes(), nodeName, diskID)
+		},
+	}
+}
+
+func (d *dashboard) confirmDeleteReplica() {
+	volume := d.detailVolume()
+	if volume == nil || len(volume.Replicas) == 0 {
+		d.message = "Open a volume and select a replica to delete"
+		return
+	}
+
+	name := volume.Replicas[d.detailCursor].Name
+	d.pending = &dashboardAction{
+		prompt: fmt.Sprintf("Delete replica %s?", name),
+		done:   fmt.Sprintf("Replica %s deleted", name),
+		run: func(ctx gocontext.Context) error {
+			return d.client.Replicas().Delete(ctx, name)
+		},
+	}
+}
@@ -0,0 +1,273 @@
+// cmd/dashboard_test.go
+package cmd
+
+import (
+	gocontext "context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+func TestParseDashboardKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "characters", input: "qr", want: []string{"q", "r"}},
+		{name: "arrows", input: "\x1b[A\x1b[B", want: []string{"up", "down"}},
+		{name: "shift-tab", input: "\x1b[Z", want: []string{"backtab"}},
+		{name: "unknown escape sequence", input: "\x1b[Cx", want: []string{"x"}},
+		{name: "escape", input: "\x1b", want: []string{"esc"}},
+		{name: "control keys", input: "\x03\t\r\n\x7f\x08", want: []string{
+			"ctrl-c", "tab", "enter", "enter", "backspace", "backspace",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseDashboardKeys([]byte(tt.input))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseDashboardKeys(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// testDashboard returns a dashboard showing two volumes and a node with two
+// disks
+func testDashboard(c *client.Client) *dashboard {
+	return &dashboard{
+		client: c,
+		out:    io.Discard,
+		data: dashboardData{
+			volumes: []client.Volume{
+				{
+					Name: "vol-a",
+					Replicas: []client.Replica{
+						{Name: "vol-a-r-1", NodeID: "node-1"},
+						{Name: "vol-a-r-2", NodeID: "node-2"},
+					},
+				},
+				{Name: "vol-b"},
+			},
+			nodes: []client.Node{{
+				Name: "node-1",
+				Disks: map[string]client.Disk{
+					"disk-2": {Path: "/data2"},
+					"disk-1": {Path: "/data1"},
+				},
+			}},
+		},
+	}
+}
+
+func TestDashboardHandleKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []string
+		wantQuit    bool
+		wantRefresh bool
+		wantPrompt  string // prompt of the confirmed action
+		wantPending string // prompt of the action waiting for confirmation
+		wantPane    int
+		wantCursor  int
+		wantDetail  string
+		wantMessage string
+	}{
+		{name: "quit", keys: []string{"q"}, wantQuit: true},
+		{name: "ctrl-c", keys: []string{"ctrl-c"}, wantQuit: true},
+		{name: "refresh", keys: []string{"r"}, wantRefresh: true},
+		{name: "next pane", keys: []string{"tab"}, wantPane: paneNodes},
+		{name: "previous pane", keys: []string{"backtab"}, wantPane: paneCount - 1},
+		{name: "move down", keys: []string{"down"}, wantCursor: 1},
+		{name: "move past the end", keys: []string{"j", "j", "j"}, wantCursor: 1},
+		{name: "move past the start", keys: []string{"up"}},
+		{name: "open volume", keys: []string{"enter"}, wantDetail: "vol-a"},
+		{name: "close volume", keys: []string{"enter", "esc"}},
+		{name: "tab in volume", keys: []string{"enter", "tab"}, wantDetail: "vol-a"},
+		{
+			name:        "detach",
+			keys:        []string{"d"},
+			wantPending: "Detach volume vol-a?",
+		},
+		{
+			name:       "detach confirmed",
+			keys:       []string{"d", "y"},
+			wantPrompt: "Detach volume vol-a?",
+		},
+		{
+			name:        "detach cancelled",
+			keys:        []string{"d", "n"},
+			wantMessage: "Cancelled",
+		},
+		{
+			name:        "evict without node",
+			keys:        []string{"e"},
+			wantMessage: "Select a node or disk to evict",
+		},
+		{
+			name:       "evict node",
+			keys:       []string{"tab", "e", "y"},
+			wantPane:   paneNodes,
+			wantPrompt: "Evict all replicas from node node-1?",
+		},
+		{
+			name:       "evict disk",
+			keys:       []string{"tab", "down", "e", "Y"},
+			wantPane:   paneNodes,
+			wantCursor: 1,
+			wantPrompt: "Evict all replicas from disk disk-1 on node node-1?",
+		},
+		{
+			name:        "delete replica outside the replica view",
+			keys:        []string{"x"},
+			wantMessage: "Open a volume and select a replica to delete",
+		},
+		{
+			name:       "delete replica",
+			keys:       []string{"enter", "down", "x", "y"},
+			wantCursor: 1,
+			wantDetail: "vol-a",
+			wantPrompt: "Delete replica vol-a-r-2?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testDashboard(nil)
+
+			var quit, refresh bool
+			var action *dashboardAction
+			for _, key := range tt.keys {
+				quit, refresh, action = d.handleKey(key)
+			}
+
+			if quit != tt.wantQuit || refresh != tt.wantRefresh {
+				t.Errorf("quit, refresh = %v, %v, want %v, %v",
+					quit, refresh, tt.wantQuit, tt.wantRefresh)
+			}
+			if prompt := promptOf(action); prompt != tt.wantPrompt {
+				t.Errorf("confirmed action = %q, want %q", prompt, tt.wantPrompt)
+			}
+			if prompt := promptOf(d.pending); prompt != tt.wantPending {
+				t.Errorf("pending action = %q, want %q", prompt, tt.wantPending)
+			}
+			if d.pane != tt.wantPane {
+				t.Errorf("pane = %d, want %d", d.pane, tt.wantPane)
+			}
+			cursor := d.cursor[d.pane]
+			if d.detail != "" {
+				cursor = d.detailCursor
+			}
+			if cursor != tt.wantCursor {
+				t.Errorf("cursor = %d, want %d", cursor, tt.wantCursor)
+			}
+			if d.detail != tt.wantDetail {
+				t.Errorf("detail = %q, want %q", d.detail, tt.wantDetail)
+			}
+			if d.message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", d.message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func promptOf(action *dashboardAction) string {
+	if action == nil {
+		return ""
+	}
+	return action.prompt
+}
+
+// newRecordingAPI starts a Longhorn manager API that accepts every request
+// and records its method, URI and body
+func newRecordingAPI(t *testing.T) (*client.Client, func() []string) {
+	var mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests,
+			strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"name": "node-1"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(&client.Config{Endpoint: server.URL, Retry: &client.RetryPolicy{}})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+func TestDashboardActions(t *testing.T) {
+	tests := []struct {
+		name         string
+		keys         []string
+		wantRequests []string
+	}{
+		{
+			name:         "detach volume",
+			keys:         []string{"d", "y"},
+			wantRequests: []string{"POST /v1/volumes/vol-a?action=detach {}"},
+		},
+		{
+			name: "evict node",
+			keys: []string{"tab", "e", "y"},
+			wantRequests: []string{
+				`PUT /v1/nodes/node-1 {"allowScheduling":false}`,
+				`PUT /v1/nodes/node-1 {"evictionRequested":true}`,
+			},
+		},
+		{
+			name: "evict disk",
+			keys: []string{"tab", "down", "e", "y"},
+			wantRequests: []string{
+				`PATCH /v1/nodes/node-1/disks/disk-1 {"allowScheduling":false}`,
+				`PATCH /v1/nodes/node-1/disks/disk-1 {"evictionRequested":true}`,
+			},
+		},
+		{
+			name:         "delete replica",
+			keys:         []string{"enter", "x", "y"},
+			wantRequests: []string{"DELETE /v1/replicas/vol-a-r-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, requests := newRecordingAPI(t)
+			d := testDashboard(c)
+
+			var action *dashboardAction
+			for _, key := range tt.keys {
+				_, _, action = d.handleKey(key)
+			}
+			if action == nil {
+				t.Fatal("no action confirmed")
+			}
+			if err := action.run(gocontext.Background()); err != nil {
+				t.Fatalf("action failed: %v", err)
+			}
+
+			got := requests()
+			if strings.Join(got, "\n") != strings.Join(tt.wantRequests, "\n") {
+				t.Errorf("requests = %q, want %q", got, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+// cmd/dashboard_view.go
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/metrics"
+	"github.com/pascal71/lhcli/pkg/utils"
+)
+
+// cell is a fixed width table cell. A width of 0 takes the remaining space.
+type cell struct {
+	text  string
+	width int
+	style func(string) string
+}
+
+// statusCell is a cell coloured with formatter.FormatStatus
+func statusCell(text string, width int) cell {
+	return cell{text: text, width: width, style: func(s string) string {
+		return formatter.FormatStatus(s, true)
+	}}
+}
+
+// fit truncates or pads s to exactly width terminal columns
+func fit(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		if width <= 1 {
+			return string(runes[:width])
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// renderRow lays out cells on a line of the given width. Styles are applied
+// after padding so that escape codes do not upset the alignment.
+func renderRow(width int, selected bool, cells ...cell) string {
+	var b strings.Builder
+	used := 2
+	if selected {
+		b.WriteString("> ")
+	} else {
+		b.WriteString("  ")
+	}
+
+	for _, c := range cells {
+		w := c.width
+		if w == 0 || used+w > width {
+			w = width - used
+		}
+		if w <= 0 {
+			break
+		}
+		text := fit(c.text, w)
+		if c.style != nil {
+			text = c.style(text)
+		}
+		b.WriteString(text)
+		used += w
+		if used < width {
+			b.WriteString(" ")
+			used++
+		}
+	}
+	return b.String()
+}
+
+// draw renders the whole screen
+func (d *dashboard) draw(fd int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 120, 40
+	}
+
+	lines := []string{d.header()}
+	if d.detail != "" {
+		lines = append(lines, d.detailLines(width, height-2)...)
+	} else {
+		lines = append(lines, d.overviewLines(width, height-2)...)
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines[:height-1], d.footer())
+
+	// Raw mode needs explicit carriage returns
+	fmt.Fprint(d.out, clearScreen+strings.Join(lines, "\r\n"))
+}
+
+func (d *dashboard) header() string {
+	counts := make(map[string]int)
+	for _, volume := range d.data.volumes {
+		counts[strings.ToLower(volume.Robustness)]++
+	}
+
+	updated := "loading..."
+	if !d.data.updated.IsZero() {
+		updated = "updated " + d.data.updated.Format("15:04:05")
+	}
+
+	return color.New(color.Bold).Sprintf(
+		"Longhorn dashboard  %d volumes (%d faulted, %d degraded)  %d nodes  %s",
+		len(d.data.volumes),
+		counts["faulted"],
+		counts["degraded"],
+		len(d.data.nodes),
+		updated,
+	)
+}
+
+func (d *dashboard) footer() string {
+	if d.pending != nil {
+		return color.New(color.FgYellow, color.Bold).Sprintf("%s [y/N]", d.pending.prompt)
+	}
+
+	help := "tab: pane  ↑/↓: move  enter: replicas  d: detach  e: evict  r: refresh  q: quit"
+	if d.detail != "" {
+		help = "esc: back  ↑/↓: move  x: delete replica  d: detach  r: refresh  q: quit"
+	}
+	if d.message != "" {
+		return d.message + "  |  " + help
+	}
+	return help
+}
+
+// overviewLines splits the screen between the four panes
+func (d *dashboard) overviewLines(width, height int) []string {
+	rebuilds := d.rebuildRows()
+
+	rebuildHeight := len(rebuilds) + 2
+	if rebuildHeight < 3 {
+		rebuildHeight = 3
+	}
+	if rebuildHeight > height/5 {
+		rebuildHeight = height / 5
+	}
+	volumeHeight := (height - rebuildHeight) * 2 / 5
+	nodeHeight := (height - rebuildHeight) * 3 / 10
+	eventHeight := height - rebuildHeight - volumeHeight - nodeHeight
+
+	panes := []struct {
+		pane   int
+		title  string
+		count  int
+		err    error
+		height int
+		rows   func(int) []string
+	}{
+		{paneVolumes, "Volumes", len(d.data.volumes), d.data.volumesErr,
+			volumeHeight, d.volumeRows(width)},
+		{paneNodes, "Nodes & Disks", len(d.data.nodes), d.data.nodesErr,
+			nodeHeight, d.nodeLines(width)},
+		{paneRebuilds, "Rebuilds", len(rebuilds), d.data.enginesErr,
+			rebuildHeight, d.rebuildLines(width, rebuilds)},
+		{paneEvents, "Recent Events", len(d.data.events), d.data.eventsErr,
+			eventHeight, d.eventLines(width)},
+	}
+
+	var lines []string
+	for _, p := range panes {
+		lines = append(lines, d.renderPane(p.pane, p.title, p.count, p.err, p.height, p.rows)...)
+	}
+	return lines
+}
+
+// renderPane renders a titled pane of the given height, scrolled so that the
+// selected row stays visible
+func (d *dashboard) renderPane(
+	pane int,
+	title string,
+	count int,
+	err error,
+	height int,
+	rows func(selected int) []string,
+) []string {
+	if height <= 0 {
+		return nil
+	}
+
+	titleLine := fmt.Sprintf("── %s (%d) ", title, count)
+	if pane == d.pane {
+		titleLine = color.New(color.FgCyan, color.Bold).Sprint(titleLine)
+	}
+	lines := []string{titleLine}
+
+	switch {
+	case err != nil:
+		lines = append(lines, color.HiBlackString("  unavailable: %v", err))
+	case count == 0:
+		lines = append(lines, color.HiBlackString("  none"))
+	default:
+		selected := -1
+		if pane == d.pane {
+			selected = d.cursor[pane]
+		}
+		body := rows(selected)
+
+		visible := height - 1
+		start := 0
+		if d.cursor[pane] >= visible {
+			start = d.cursor[pane] - visible + 1
+		}
+		end := start + visible
+		if end > len(body) {
+			end = len(body)
+		}
+		lines = append(lines, body[start:end]...)
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lines[:height]
+}
+
+func (d *dashboard) volumeRows(width int) func(int) []string {
+	return func(selected int) []string {
+		rows := []string{}
+		for i, volume := range d.data.volumes {
+			healthy := 0
+			nodes := []string{}
+			for _, replica := range volume.Replicas {
+				if metrics.IsReplicaHealthy(replica) {
+					healthy++
+				}
+				nodes = append(nodes, replica.NodeID)
+			}
+
+			robustness := volume.Robustness
+			if robustness == "" {
+				robustness = "Unknown"
+			}
+
+			rows = append(rows, renderRow(width, i == selected,
+				cell{text: volume.Name, width: 40},
+				cell{text: getVolumeState(volume), width: 10},
+				statusCell(robustness, 10),
+				cell{text: fmt.Sprintf("%d/%d", healthy, volume.NumberOfReplicas), width: 5},
+				cell{text: volumeSize(volume), width: 10},
+				cell{text: strings.Join(nodes, ",")},
+			))
+		}
+		return rows
+	}
+}
+
+func (d *dashboard) nodeLines(width int) func(int) []string {
+	return func(selected int) []string {
+		rows := []string{}
+		for i, row := range d.nodeRows() {
+			if row.diskID == "" {
+				scheduling := "enabled"
+				if !row.node.AllowScheduling {
+					scheduling = "disabled"
+				}
+				if row.node.EvictionRequested {
+					scheduling = "evicting"
+				}
+				rows = append(rows, renderRow(width, i == selected,
+					cell{text: row.node.Name, width: 30},
+					statusCell(getNodeStatus(row.node), 10),
+					cell{text: scheduling, width: 10},
+					cell{text: row.node.Zone},
+				))
+				continue
+			}
+
+			disk := row.node.Disks[row.diskID]
+			used := disk.StorageMaximum - disk.StorageAvailable
+			rows = append(rows, renderRow(width, i == selected,
+				cell{text: "  └ " + row.diskID, width: 30},
+				cell{text: disk.Path, width: 21},
+				capacityBar(used, disk.StorageMaximum, 22),
+				cell{text: fmt.Sprintf(
+					"%s / %s",
+					utils.FormatSize(used),
+					utils.FormatSize(disk.StorageMaximum),
+				)},
+			))
+		}
+		return rows
+	}
+}
+
+// capacityBar draws a usage bar coloured by how full the disk is
+func capacityBar(used, total int64, width int) cell {
+	ratio := 0.0
+	if total > 0 {
+		ratio = float64(used) / float64(total)
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	barWidth := width - 7
+	filled := int(ratio * float64(barWidth))
+	bar := fmt.Sprintf(
+		"[%s%s] %3.0f%%",
+		strings.Repeat("#", filled),
+		strings.Repeat("-", barWidth-filled),
+		ratio*100,
+	)
+
+	style := color.GreenString
+	switch {
+	case ratio >= 0.85:
+		style = color.RedString
+	case ratio >= 0.70:
+		style = color.YellowString
+	}
+	return cell{text: bar, width: width, style: func(s string) string { return style(s) }}
+}
+
+func (d *dashboard) rebuildLines(width int, rebuilds []rebuildRow) func(int) []string {
+	return func(selected int) []string {
+		rows := []string{}
+		for i, r := range rebuilds {
+			rows = append(rows, renderRow(width, i == selected,
+				cell{text: r.volume, width: 40},
+				cell{text: r.replica, width: 22},
+				cell{text: fmt.Sprintf("%d%%", r.status.Progress), width: 5},
+				cell{text: "from " + r.status.FromReplicaAddress},
+			))
+		}
+		return rows
+	}
+}
+
+func (d *dashboard) eventLines(width int) func(int) []string {
+	return func(selected int) []string {
+		rows := []string{}
+		for i, event := range d.data.events {
+			typeStyle := func(s string) string { return s }
+			if event.Type == "Warning" {
+				typeStyle = func(s string) string { return color.YellowString(s) }
+			}
+			rows = append(rows, renderRow(width, i == selected,
+				cell{text: event.LastTimestamp.Format("15:04:05"), width: 8},
+				cell{text: event.Type, width: 7, style: typeStyle},
+				cell{text: event.Object, width: 35},
+				cell{text: event.Reason, width: 20},
+				cell{text: event.Message},
+			))
+		}
+		return rows
+	}
+}
+
+// detailLines shows a single volume and its replicas
+func (d *dashboard) detailLines(width, height int) []string {
+	volume := d.detailVolume()
+	if volume == nil {
+		return []string{"Volume not found"}
+	}
+
+	robustness := volume.Robustness
+	if robustness == "" {
+		robustness = "Unknown"
+	}
+
+	lines := []string{
+		color.New(color.FgCyan, color.Bold).Sprintf("── Volume %s ", volume.Name),
+		fmt.Sprintf("  State: %s   Robustness: %s   Size: %s   Replicas: %d",
+			getVolumeState(*volume),
+			formatter.FormatStatus(robustness, true),
+			volumeSize(*volume),
+			volume.NumberOfReplicas),
+		fmt.Sprintf("  Frontend: %s   Access mode: %s   Data locality: %s   Last backup: %s",
+			volume.Frontend, volume.AccessMode, volume.DataLocality, volume.LastBackupAt),
+		"",
+		color.New(color.Bold).Sprint(renderRow(width, false,
+			cell{text: "REPLICA", width: 45},
+			cell{text: "NODE", width: 20},
+			cell{text: "MODE", width: 8},
+			cell{text: "HEALTH", width: 10},
+			cell{text: "DISK"},
+		)),
+	}
+
+	for i, replica := range volume.Replicas {
+		health := "Healthy"
+		if !metrics.IsReplicaHealthy(replica) {
+			health = "Failed"
+			if replica.FailedAt == "" {
+				health = "Rebuilding"
+			}
+		}
+		disk := replica.DiskPath
+		if disk == "" {
+			disk = replica.DiskID
+		}
+		lines = append(lines, renderRow(width, i == d.detailCursor,
+			cell{text: replica.Name, width: 45},
+			cell{text: replica.NodeID, width: 20},
+			cell{text: replica.Mode, width: 8},
+			statusCell(health, 10),
+			cell{text: disk},
+		))
+	}
+	if len(volume.Replicas) == 0 {
+		lines = append(lines, color.HiBlackString("  no replicas"))
+	}
+
+	for _, r := range d.rebuildRows() {
+		if r.volume == volume.Name {
+			lines = append(lines, fmt.Sprintf("  Rebuilding %s: %d%%", r.replica, r.status.Progress))
+		}
+	}
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return lines
+}
+
+// volumeSize formats the volume size if it is a number of bytes
+func volumeSize(volume client.Volume) string {
+	if size, err := strconv.ParseInt(volume.Size, 10, 64); err == nil {
+		return utils.FormatSize(size)
+	}
+	return volume.Size
+}
@@ -0,0 +1,130 @@
+// cmd/health.go
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/health"
+)
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check the health of the Longhorn system",
+	Long:  `Check the health of the Longhorn system.`,
+}
+
+var healthCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Run health checks",
+	Long: `Run independent health checks against the Longhorn system and report PASS,
+WARN or FAIL for each of them, with a hint on how to fix problems.
+
+Checks:
+  node-ready          all Longhorn nodes are ready
+  node-schedulable    all Longhorn nodes accept new replicas
+  disks               all disks are ready and schedulable
+  volumes             no volume is degraded or faulted
+  backup-target       the backup target is configured and available
+  engine-images       engine images in use are deployed on all ready nodes
+  orphaned-replicas   every replica belongs to an existing volume
+  instance-managers   instance managers are running and up to date
+
+A check that cannot read the resources it needs reports WARN.
+
+Exit codes:
+  0  no check failed
+  2  at least one check failed (or warned, with --strict)
+  1  the health check itself failed`,
+	RunE: runHealthCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(healthCmd)
+	healthCmd.AddCommand(healthCheckCmd)
+
+	// Health check flags
+	healthCheckCmd.Flags().Bool("detailed", false, "Show every problem found by each check")
+	healthCheckCmd.Flags().Bool("strict", false, "Treat warnings as failures")
+}
+
+func runHealthCheck(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	detailed, _ := cmd.Flags().GetBool("detailed")
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	report := health.Run(health.NewClientSource(ctx, c), health.DefaultChecks())
+
+	switch output {
+	case "json":
+		err = formatter.NewJSONFormatter(true).Format(report)
+	case "yaml":
+		err = formatter.NewYAMLFormatter().Format(report)
+	default:
+		err = printHealthReport(report, detailed)
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.Status == health.StatusFail || (strict && report.Status == health.StatusWarn) {
+		return silentExit(cmd, 2, fmt.Sprintf("health check status is %s", report.Status))
+	}
+	return nil
+}
+
+func printHealthReport(report *health.Report, detailed bool) error {
+	headers := []string{"CHECK", "STATUS", "MESSAGE"}
+	table := formatter.NewTableFormatter(headers)
+	for _, result := range report.Results {
+		table.AddRow([]string{result.Check, colorHealthStatus(result.Status), result.Message})
+	}
+	if err := table.Format(nil); err != nil {
+		return err
+	}
+
+	printed := false
+	for _, result := range report.Results {
+		if result.Status == health.StatusPass {
+			continue
+		}
+		if !printed {
+			fmt.Println()
+			printed = true
+		}
+
+		fmt.Printf("%s %s\n", colorHealthStatus(result.Status), result.Check)
+		if detailed {
+			for _, finding := range result.Findings {
+				fmt.Printf("  - %s: %s\n", finding.Status, finding.Message)
+			}
+		}
+		if result.Hint != "" {
+			fmt.Printf("  Hint: %s\n", result.Hint)
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d warnings, %d failed. Status: %s\n",
+		report.Passed, report.Warnings, report.Failed, colorHealthStatus(report.Status))
+	return nil
+}
+
+func colorHealthStatus(status string) string {
+	switch status {
+	case health.StatusFail:
+		return color.New(color.FgRed, color.Bold).Sprint(status)
+	case health.StatusWarn:
+		return color.New(color.FgYellow, color.Bold).Sprint(status)
+	default:
+		return color.New(color.FgGreen, color.Bold).Sprint(status)
+	}
+}
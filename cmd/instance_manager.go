@@ -0,0 +1,239 @@
+// cmd/instance_manager.go
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+)
+
+var instanceManagerCmd = &cobra.Command{
+	Use:     "instance-manager",
+	Aliases: []string{"im"},
+	Short:   "Inspect Longhorn instance managers",
+	Long: `Inspect Longhorn instance managers, the pods that run the engine and replica
+processes of volumes on each node.`,
+}
+
+var instanceManagerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List instance managers",
+	Long: `List instance managers with their node, type (engine, replica or aio), data
+engine (v1 or v2), state and the number of instances and volumes they serve.`,
+	RunE: runInstanceManagerList,
+}
+
+var instanceManagerGetCmd = &cobra.Command{
+	Use:   "get [instance-manager-name]",
+	Short: "Get instance manager details",
+	Long: `Get detailed information about an instance manager, including the engine and
+replica instances it runs and the volumes that are affected if it fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInstanceManagerGet,
+}
+
+func init() {
+	rootCmd.AddCommand(instanceManagerCmd)
+	instanceManagerCmd.AddCommand(instanceManagerListCmd)
+	instanceManagerCmd.AddCommand(instanceManagerGetCmd)
+
+	// Instance manager list flags
+	instanceManagerListCmd.Flags().String("node", "", "Filter instance managers by node")
+}
+
+// instanceManagerDetails is an instance manager with the volumes it serves
+type instanceManagerDetails struct {
+	client.InstanceManager `json:",inline" yaml:",inline"`
+	Volumes                []string `json:"volumes"`
+}
+
+func runInstanceManagerList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName, _ := cmd.Flags().GetString("node")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	managers, err := c.InstanceManagers().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list instance managers: %w", err)
+	}
+
+	volumes, err := instanceManagerVolumes(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	details := make([]instanceManagerDetails, 0, len(managers))
+	for _, manager := range managers {
+		if nodeName != "" && manager.NodeID != nodeName {
+			continue
+		}
+		details = append(details, instanceManagerDetails{
+			InstanceManager: manager,
+			Volumes:         volumes[manager.Name],
+		})
+	}
+	sort.Slice(details, func(i, j int) bool {
+		if details[i].NodeID != details[j].NodeID {
+			return details[i].NodeID < details[j].NodeID
+		}
+		return details[i].Name < details[j].Name
+	})
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(details)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(details)
+	default:
+		return printInstanceManagersTable(details, output == "wide")
+	}
+}
+
+func runInstanceManagerGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	name := args[0]
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	manager, err := c.InstanceManagers().Get(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get instance manager: %w", err)
+	}
+
+	volumes, err := instanceManagerVolumes(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	details := instanceManagerDetails{InstanceManager: *manager, Volumes: volumes[manager.Name]}
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(details)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(details)
+	default:
+		return printInstanceManagerDetails(details)
+	}
+}
+
+// instanceManagerVolumes maps instance manager names to the volumes whose
+// engines or replicas they run. The engines and replicas record their
+// instance manager, so volumes are found even if the instance manager has
+// crashed and lost its instances.
+func instanceManagerVolumes(ctx gocontext.Context, c *client.Client) (map[string][]string, error) {
+	engines, err := c.Engines().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list engines: %w", err)
+	}
+	replicas, err := c.Replicas().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicas: %w", err)
+	}
+
+	seen := make(map[string]map[string]bool)
+	add := func(manager, volume string) {
+		if manager == "" || volume == "" {
+			return
+		}
+		if seen[manager] == nil {
+			seen[manager] = make(map[string]bool)
+		}
+		seen[manager][volume] = true
+	}
+	for _, engine := range engines {
+		add(engine.InstanceManager, engine.VolumeName)
+	}
+	for _, replica := range replicas {
+		add(replica.InstanceManager, replica.VolumeName)
+	}
+
+	result := make(map[string][]string, len(seen))
+	for manager, volumes := range seen {
+		for volume := range volumes {
+			result[manager] = append(result[manager], volume)
+		}
+		sort.Strings(result[manager])
+	}
+	return result, nil
+}
+
+func printInstanceManagersTable(managers []instanceManagerDetails, wide bool) error {
+	headers := []string{"NAME", "NODE", "TYPE", "DATA ENGINE", "STATE", "INSTANCES", "VOLUMES"}
+	if wide {
+		headers = append(headers, "IP", "IMAGE")
+	}
+	table := formatter.NewTableFormatter(headers)
+
+	for _, manager := range managers {
+		row := []string{
+			manager.Name,
+			manager.NodeID,
+			manager.Type,
+			manager.DataEngine,
+			formatter.FormatStatus(manager.CurrentState, true),
+			fmt.Sprintf("%d", len(manager.Instances)),
+			fmt.Sprintf("%d", len(manager.Volumes)),
+		}
+		if wide {
+			row = append(row, manager.IP, manager.Image)
+		}
+		table.AddRow(row)
+	}
+
+	return table.Format(nil)
+}
+
+func printInstanceManagerDetails(manager instanceManagerDetails) error {
+	fmt.Printf("Name:              %s\n", manager.Name)
+	fmt.Printf("Node:              %s\n", manager.NodeID)
+	fmt.Printf("Type:              %s\n", manager.Type)
+	fmt.Printf("Data Engine:       %s\n", manager.DataEngine)
+	fmt.Printf("State:             %s\n", formatter.FormatStatus(manager.CurrentState, true))
+	fmt.Printf("IP:                %s\n", manager.IP)
+	fmt.Printf("Image:             %s\n", manager.Image)
+
+	fmt.Printf("\nInstances (%d):\n", len(manager.Instances))
+	if len(manager.Instances) > 0 {
+		headers := []string{"NAME", "TYPE", "STATE", "PORT", "ERROR"}
+		table := formatter.NewTableFormatter(headers)
+		for _, instance := range manager.Instances {
+			port := "-"
+			if instance.Port > 0 {
+				port = fmt.Sprintf("%d", instance.Port)
+			}
+			table.AddRow([]string{
+				instance.Name,
+				instance.Type,
+				formatter.FormatStatus(instance.State, true),
+				port,
+				instance.ErrorMsg,
+			})
+		}
+		if err := table.Format(nil); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\nAffected Volumes (%d):\n", len(manager.Volumes))
+	if len(manager.Volumes) > 0 {
+		fmt.Printf("  %s\n", strings.Join(manager.Volumes, "\n  "))
+	}
+
+	return nil
+}
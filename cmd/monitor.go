@@ -1,58 +1,357 @@
 package cmd
 
 import (
-    "fmt"
-    
-    "github.com/spf13/cobra"
+	"bytes"
+	gocontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/metrics"
+	"github.com/pascal71/lhcli/pkg/monitor"
+	"github.com/pascal71/lhcli/pkg/utils"
 )
 
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
 var monitorCmd = &cobra.Command{
-    Use:   "monitor",
-    Short: "Monitor Longhorn resources",
-    Long:  `Real-time monitoring of Longhorn resources including volumes, nodes, and events.`,
+	Use:   "monitor",
+	Short: "Monitor Longhorn resources",
+	Long:  `Real-time monitoring of Longhorn resources including volumes, nodes, and events.`,
 }
 
 var monitorVolumesCmd = &cobra.Command{
-    Use:   "volumes",
-    Short: "Monitor volumes",
-    Long:  `Monitor Longhorn volumes in real-time.`,
-    Run: func(cmd *cobra.Command, args []string) {
-        interval, _ := cmd.Flags().GetString("interval")
-        fmt.Printf("Monitoring volumes (interval: %s)...\n", interval)
-        // TODO: Implement volume monitoring logic
-    },
+	Use:   "volumes",
+	Short: "Monitor volumes",
+	Long: `Monitor Longhorn volumes in real-time.
+
+The table of volume state, robustness, size and replica health is redrawn
+whenever a volume or replica changes. When connected through the Kubernetes
+API the changes are received through a watch; with the Longhorn HTTP API the
+volumes are polled every --interval.`,
+	RunE: runMonitorVolumes,
 }
 
 var monitorNodesCmd = &cobra.Command{
-    Use:   "nodes",
-    Short: "Monitor nodes",
-    Long:  `Monitor Longhorn nodes in real-time.`,
-    Run: func(cmd *cobra.Command, args []string) {
-        interval, _ := cmd.Flags().GetString("interval")
-        fmt.Printf("Monitoring nodes (interval: %s)...\n", interval)
-        // TODO: Implement node monitoring logic
-    },
+	Use:   "nodes",
+	Short: "Monitor nodes",
+	Long:  `Monitor Longhorn nodes in real-time.`,
+	RunE:  runMonitorNodes,
 }
 
 var monitorEventsCmd = &cobra.Command{
-    Use:   "events",
-    Short: "Monitor events",
-    Long:  `Monitor Longhorn events in real-time.`,
-    Run: func(cmd *cobra.Command, args []string) {
-        follow, _ := cmd.Flags().GetBool("follow")
-        fmt.Printf("Monitoring events (follow: %v)...\n", follow)
-        // TODO: Implement event monitoring logic
-    },
+	Use:   "events",
+	Short: "Monitor events",
+	Long: `Monitor Longhorn events in real-time.
+
+Shows the Kubernetes events of Longhorn resources such as volumes, engines,
+replicas and nodes. With --follow new events are streamed as they occur; an
+event that repeats is shown again each time its count increases.`,
+	RunE: runMonitorEvents,
 }
 
 func init() {
-    rootCmd.AddCommand(monitorCmd)
-    monitorCmd.AddCommand(monitorVolumesCmd)
-    monitorCmd.AddCommand(monitorNodesCmd)
-    monitorCmd.AddCommand(monitorEventsCmd)
-    
-    // Monitor flags
-    monitorVolumesCmd.Flags().String("interval", "5s", "Refresh interval")
-    monitorNodesCmd.Flags().String("interval", "5s", "Refresh interval")
-    monitorEventsCmd.Flags().Bool("follow", false, "Follow event stream")
+	rootCmd.AddCommand(monitorCmd)
+	monitorCmd.AddCommand(monitorVolumesCmd)
+	monitorCmd.AddCommand(monitorNodesCmd)
+	monitorCmd.AddCommand(monitorEventsCmd)
+
+	// Monitor flags
+	monitorVolumesCmd.Flags().
+		Duration("interval", 5*time.Second, "Refresh interval when watching is not supported")
+	monitorNodesCmd.Flags().Duration("interval", 5*time.Second, "Refresh interval")
+	monitorEventsCmd.Flags().BoolP("follow", "f", false, "Follow event stream")
+	monitorEventsCmd.Flags().
+		Duration("since", 0, "Only show events newer than this duration (e.g. 30m, 2h)")
+	monitorEventsCmd.Flags().String("type", "", "Only show events of this type (Normal|Warning)")
+	monitorEventsCmd.Flags().
+		String("resource-type", "", "Only show events of this resource type (e.g. volume, engine)")
+	monitorEventsCmd.Flags().String("name", "", "Only show events of the resource with this name")
+}
+
+func runMonitorVolumes(cmd *cobra.Command, args []string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	m := monitor.NewVolumeMonitor(c, func(volumes []client.Volume) {
+		drawScreen(func(buf *bytes.Buffer) error {
+			return renderVolumeMonitor(buf, volumes)
+		})
+	})
+	return ignoreCanceled(m.Start(ctx, interval))
+}
+
+func runMonitorNodes(cmd *cobra.Command, args []string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	m := monitor.NewNodeMonitor(c, func(nodes []client.Node) {
+		drawScreen(func(buf *bytes.Buffer) error {
+			return renderNodeMonitor(buf, nodes)
+		})
+	})
+	return ignoreCanceled(m.Start(ctx, interval))
+}
+
+func runMonitorEvents(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	follow, _ := cmd.Flags().GetBool("follow")
+	since, _ := cmd.Flags().GetDuration("since")
+	eventType, _ := cmd.Flags().GetString("type")
+	resourceType, _ := cmd.Flags().GetString("resource-type")
+	name, _ := cmd.Flags().GetString("name")
+
+	opts := client.EventListOptions{
+		ResourceType: resourceType,
+		ResourceName: name,
+	}
+	switch strings.ToLower(eventType) {
+	case "":
+	case "normal":
+		opts.EventType = "Normal"
+	case "warning":
+		opts.EventType = "Warning"
+	default:
+		return fmt.Errorf("invalid event type %q (must be Normal or Warning)", eventType)
+	}
+	if since > 0 {
+		opts.Since = time.Now().Add(-since)
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	if !follow {
+		events, err := c.Events().List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list events: %w", err)
+		}
+
+		switch output {
+		case "json":
+			return formatter.NewJSONFormatter(true).Format(events)
+		case "yaml":
+			return formatter.NewYAMLFormatter().Format(events)
+		default:
+			return printEventsTable(events)
+		}
+	}
+
+	err = followEvents(ctx, c.Events(), opts, os.Stdout, output == "json")
+	if err = ignoreCanceled(err); err != nil {
+		return fmt.Errorf("failed to follow events: %w", err)
+	}
+	return nil
+}
+
+// followEvents writes events to w as they happen, as a table or as one JSON
+// object per line. A failed write, e.g. to a closed pipe, ends the watch.
+func followEvents(
+	ctx gocontext.Context,
+	events client.EventInterface,
+	opts client.EventListOptions,
+	w io.Writer,
+	jsonOutput bool,
+) error {
+	ctx, stop := gocontext.WithCancel(ctx)
+	defer stop()
+
+	if !jsonOutput {
+		header := formatEventLine("LAST SEEN", "TYPE", "REASON", "OBJECT", "COUNT", "MESSAGE")
+		if _, err := fmt.Fprintln(w, header); err != nil {
+			return err
+		}
+	}
+
+	var writeErr error
+	err := events.Watch(ctx, opts, func(event client.Event) {
+		if writeErr != nil {
+			return
+		}
+		if jsonOutput {
+			// One object per line so the stream can be processed with jq
+			writeErr = formatter.NewJSONFormatterWithWriter(false, w).Format(event)
+		} else {
+			_, writeErr = fmt.Fprintln(w, formatEventLine(
+				event.LastTimestamp.Format("15:04:05"),
+				event.Type,
+				event.Reason,
+				event.Object,
+				fmt.Sprintf("%d", event.Count),
+				event.Message,
+			))
+		}
+		if writeErr != nil {
+			stop()
+		}
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+func printEventsTable(events []client.Event) error {
+	if len(events) == 0 {
+		fmt.Println("No events found")
+		return nil
+	}
+
+	headers := []string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "COUNT", "MESSAGE"}
+	table := formatter.NewTableFormatter(headers)
+	for _, event := range events {
+		table.AddRow([]string{
+			formatter.FormatAge(event.LastTimestamp),
+			event.Type,
+			event.Reason,
+			event.Object,
+			fmt.Sprintf("%d", event.Count),
+			event.Message,
+		})
+	}
+	return table.Format(nil)
+}
+
+// formatEventLine lays out a followed event with fixed columns, as a table
+// cannot be aligned while it is still being written
+func formatEventLine(lastSeen, eventType, reason, object, count, message string) string {
+	return strings.Join([]string{
+		formatter.PadRight(lastSeen, 10),
+		formatter.PadRight(eventType, 8),
+		formatter.PadRight(reason, 24),
+		formatter.PadRight(object, 48),
+		formatter.PadRight(count, 6),
+		message,
+	}, " ")
+}
+
+// ignoreCanceled treats a cancelled context as a normal exit
+func ignoreCanceled(err error) error {
+	if errors.Is(err, gocontext.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// drawScreen renders a full screen into a buffer and writes it in one go to
+// avoid flicker
+func drawScreen(render func(buf *bytes.Buffer) error) {
+	var buf bytes.Buffer
+	buf.WriteString(clearScreen)
+	if err := render(&buf); err != nil {
+		fmt.Fprintf(&buf, "Error: %v\n", err)
+	}
+	os.Stdout.Write(buf.Bytes())
+}
+
+func renderVolumeMonitor(buf *bytes.Buffer, volumes []client.Volume) error {
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+
+	counts := make(map[string]int)
+	for _, volume := range volumes {
+		counts[strings.ToLower(volume.Robustness)]++
+	}
+	fmt.Fprintf(
+		buf,
+		"Volumes: %d total, %d healthy, %d degraded, %d faulted   (updated %s, Ctrl-C to exit)\n\n",
+		len(volumes),
+		counts["healthy"],
+		counts["degraded"],
+		counts["faulted"],
+		time.Now().Format("15:04:05"),
+	)
+
+	headers := []string{"NAME", "STATE", "SIZE", "ACTUAL", "REPLICAS", "ROBUSTNESS"}
+	table := formatter.NewTableFormatterWithWriter(headers, buf)
+	for _, volume := range volumes {
+		size := volume.Size
+		if sizeInt, err := strconv.ParseInt(volume.Size, 10, 64); err == nil {
+			size = utils.FormatSize(sizeInt)
+		}
+
+		healthy := 0
+		for _, replica := range volume.Replicas {
+			if metrics.IsReplicaHealthy(replica) {
+				healthy++
+			}
+		}
+
+		robustness := volume.Robustness
+		if robustness == "" {
+			robustness = "Unknown"
+		}
+
+		table.AddRow([]string{
+			volume.Name,
+			getVolumeState(volume),
+			size,
+			utils.FormatSize(volume.ActualSize),
+			fmt.Sprintf("%d/%d", healthy, volume.NumberOfReplicas),
+			formatter.FormatStatus(robustness, true),
+		})
+	}
+	return table.Format(nil)
+}
+
+func renderNodeMonitor(buf *bytes.Buffer, nodes []client.Node) error {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	fmt.Fprintf(buf, "Nodes: %d total   (updated %s, Ctrl-C to exit)\n\n",
+		len(nodes), time.Now().Format("15:04:05"))
+
+	headers := []string{"NAME", "SCHEDULABLE", "DISKS", "REPLICAS", "ZONE", "STATUS"}
+	table := formatter.NewTableFormatterWithWriter(headers, buf)
+	for _, node := range nodes {
+		replicaCount := 0
+		for _, disk := range node.Disks {
+			replicaCount += len(disk.ScheduledReplica)
+		}
+
+		status := getNodeStatus(node)
+		if status == "Ready" {
+			status = color.GreenString(status)
+		} else {
+			status = color.RedString(status)
+		}
+
+		table.AddRow([]string{
+			node.Name,
+			formatter.FormatBool(node.AllowScheduling),
+			fmt.Sprintf("%d", len(node.Disks)),
+			fmt.Sprintf("%d", replicaCount),
+			node.Zone,
+			status,
+		})
+	}
+	return table.Format(nil)
 }
@@ -0,0 +1,79 @@
+// cmd/monitor_test.go
+package cmd
+
+import (
+	gocontext "context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// endlessEvents is an event client whose watch reports events until it is
+// cancelled
+type endlessEvents struct {
+	client.EventInterface
+	reported int
+}
+
+func (e *endlessEvents) Watch(
+	ctx gocontext.Context,
+	opts client.EventListOptions,
+	callback func(client.Event),
+) error {
+	for e.reported < 1000 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e.reported++
+		callback(client.Event{Type: "Normal", Reason: "Attached", Object: "volume/vol-1"})
+	}
+	return errors.New("watch was not stopped")
+}
+
+// brokenPipe is a writer that fails once limit writes have succeeded
+type brokenPipe struct {
+	strings.Builder
+	limit int
+}
+
+var errBrokenPipe = errors.New("broken pipe")
+
+func (b *brokenPipe) Write(p []byte) (int, error) {
+	if b.limit == 0 {
+		return 0, errBrokenPipe
+	}
+	b.limit--
+	return b.Builder.Write(p)
+}
+
+func TestFollowEventsWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		jsonOutput bool
+		limit      int
+		wantEvents int
+	}{
+		{name: "json", jsonOutput: true, limit: 2, wantEvents: 3},
+		{name: "table", limit: 3, wantEvents: 3},
+		{name: "table header", limit: 0, wantEvents: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &endlessEvents{}
+			w := &brokenPipe{limit: tt.limit}
+
+			err := followEvents(gocontext.Background(), events, client.EventListOptions{},
+				w, tt.jsonOutput)
+
+			if !errors.Is(err, errBrokenPipe) {
+				t.Errorf("followEvents() error = %v, want %v", err, errBrokenPipe)
+			}
+			if events.reported != tt.wantEvents {
+				t.Errorf("watch reported %d events, want %d", events.reported, tt.wantEvents)
+			}
+		})
+	}
+}
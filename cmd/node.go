@@ -2,14 +2,17 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/pascal71/lhcli/pkg/client"
 	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/health"
 	"github.com/pascal71/lhcli/pkg/utils"
 )
 
@@ -126,6 +129,16 @@ Existing replicas will remain but should be migrated before removing the disk.`,
 	RunE: runNodeDiskDisable,
 }
 
+var nodeDiskEvictCmd = &cobra.Command{
+	Use:   "evict [node-name] [disk-id]",
+	Short: "Evict all replicas from a disk",
+	Long: `Disable scheduling on a disk, request eviction of its replicas and wait until
+no replicas are scheduled on it any more. With --remove the disk is removed
+from the node once it is empty.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runNodeDiskEvict,
+}
+
 func init() {
 	rootCmd.AddCommand(nodeCmd)
 
@@ -151,6 +164,7 @@ func init() {
 	nodeDiskCmd.AddCommand(nodeDiskUpdateCmd)
 	nodeDiskCmd.AddCommand(nodeDiskEnableCmd)
 	nodeDiskCmd.AddCommand(nodeDiskDisableCmd)
+	nodeDiskCmd.AddCommand(nodeDiskEvictCmd)
 
 	// Node evict flags
 	nodeEvictCmd.Flags().Bool("force", false, "Force eviction without confirmation")
@@ -161,6 +175,18 @@ func init() {
 	nodeDiskAddCmd.Flags().StringSlice("tags", []string{}, "Disk tags")
 	nodeDiskAddCmd.MarkFlagRequired("path")
 
+	// Disk remove flags
+	nodeDiskRemoveCmd.Flags().
+		Bool("force", false, "Remove the disk even if replicas are still scheduled on it")
+
+	// Disk evict flags
+	nodeDiskEvictCmd.Flags().Bool("force", false, "Evict without confirmation")
+	nodeDiskEvictCmd.Flags().Bool("remove", false, "Remove the disk once all replicas are evicted")
+	nodeDiskEvictCmd.Flags().Bool("no-wait", false, "Request eviction and return without waiting")
+	nodeDiskEvictCmd.Flags().Duration("interval", 5*time.Second, "Polling interval while waiting")
+	nodeDiskEvictCmd.Flags().
+		Duration("wait-timeout", 30*time.Minute, "Maximum time to wait for the eviction to finish")
+
 	// Disk update flags
 	nodeDiskUpdateCmd.Flags().StringSlice("tags", []string{}, "Update disk tags")
 	nodeDiskUpdateCmd.Flags().Bool("allow-scheduling", true, "Allow scheduling on this disk")
@@ -168,12 +194,14 @@ func init() {
 }
 
 func runNodeList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	c, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	nodes, err := c.Nodes().List()
+	nodes, err := c.Nodes().List(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list nodes: %w", err)
 	}
@@ -192,6 +220,8 @@ func runNodeList(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 
 	c, err := getClient()
@@ -199,7 +229,7 @@ func runNodeGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	node, err := c.Nodes().Get(nodeName)
+	node, err := c.Nodes().Get(ctx, nodeName)
 	if err != nil {
 		return fmt.Errorf("failed to get node: %w", err)
 	}
@@ -216,6 +246,8 @@ func runNodeGet(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeSchedulingEnable(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 
 	c, err := getClient()
@@ -223,7 +255,7 @@ func runNodeSchedulingEnable(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Nodes().EnableScheduling(nodeName); err != nil {
+	if err := c.Nodes().EnableScheduling(ctx, nodeName); err != nil {
 		return fmt.Errorf("failed to enable scheduling: %w", err)
 	}
 
@@ -232,6 +264,8 @@ func runNodeSchedulingEnable(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeSchedulingDisable(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 
 	c, err := getClient()
@@ -239,7 +273,7 @@ func runNodeSchedulingDisable(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Nodes().DisableScheduling(nodeName); err != nil {
+	if err := c.Nodes().DisableScheduling(ctx, nodeName); err != nil {
 		return fmt.Errorf("failed to disable scheduling: %w", err)
 	}
 
@@ -248,6 +282,8 @@ func runNodeSchedulingDisable(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeEvict(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 	force, _ := cmd.Flags().GetBool("force")
 
@@ -264,7 +300,7 @@ func runNodeEvict(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Nodes().EvictNode(nodeName); err != nil {
+	if err := c.Nodes().EvictNode(ctx, nodeName); err != nil {
 		return fmt.Errorf("failed to evict node: %w", err)
 	}
 
@@ -273,6 +309,8 @@ func runNodeEvict(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeTagAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 	tag := args[1]
 
@@ -281,7 +319,7 @@ func runNodeTagAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Nodes().AddNodeTag(nodeName, tag); err != nil {
+	if err := c.Nodes().AddNodeTag(ctx, nodeName, tag); err != nil {
 		return fmt.Errorf("failed to add tag: %w", err)
 	}
 
@@ -290,6 +328,8 @@ func runNodeTagAdd(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeTagRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 	tag := args[1]
 
@@ -298,7 +338,7 @@ func runNodeTagRemove(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Nodes().RemoveNodeTag(nodeName, tag); err != nil {
+	if err := c.Nodes().RemoveNodeTag(ctx, nodeName, tag); err != nil {
 		return fmt.Errorf("failed to remove tag: %w", err)
 	}
 
@@ -307,6 +347,8 @@ func runNodeTagRemove(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeDiskAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 
 	path, _ := cmd.Flags().GetString("path")
@@ -330,7 +372,7 @@ func runNodeDiskAdd(cmd *cobra.Command, args []string) error {
 		Tags:            tags,
 	}
 
-	if err := c.Nodes().AddDisk(nodeName, disk); err != nil {
+	if err := c.Nodes().AddDisk(ctx, nodeName, disk); err != nil {
 		return fmt.Errorf("failed to add disk: %w", err)
 	}
 
@@ -339,15 +381,24 @@ func runNodeDiskAdd(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeDiskRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 	diskID := args[1]
+	force, _ := cmd.Flags().GetBool("force")
 
 	c, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	if err := c.Nodes().RemoveDisk(nodeName, diskID); err != nil {
+	opts := client.DiskRemoveOptions{Force: force}
+	if err := c.Nodes().RemoveDisk(ctx, nodeName, diskID, opts); err != nil {
+		var inUse *client.DiskInUseError
+		if errors.As(err, &inUse) {
+			return fmt.Errorf("%w; run 'lhcli node disk evict %s %s' first or use --force",
+				err, nodeName, diskID)
+		}
 		return fmt.Errorf("failed to remove disk: %w", err)
 	}
 
@@ -356,6 +407,8 @@ func runNodeDiskRemove(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeDiskUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 	diskID := args[1]
 
@@ -367,7 +420,7 @@ func runNodeDiskUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(tags) > 0 {
-		if err := c.Nodes().UpdateDiskTags(nodeName, diskID, tags); err != nil {
+		if err := c.Nodes().UpdateDiskTags(ctx, nodeName, diskID, tags); err != nil {
 			return fmt.Errorf("failed to update disk tags: %w", err)
 		}
 	}
@@ -377,6 +430,8 @@ func runNodeDiskUpdate(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeDiskEnable(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 	diskID := args[1]
 
@@ -385,7 +440,7 @@ func runNodeDiskEnable(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Nodes().EnableDiskScheduling(nodeName, diskID); err != nil {
+	if err := c.Nodes().EnableDiskScheduling(ctx, nodeName, diskID); err != nil {
 		return fmt.Errorf("failed to enable disk: %w", err)
 	}
 
@@ -394,6 +449,8 @@ func runNodeDiskEnable(cmd *cobra.Command, args []string) error {
 }
 
 func runNodeDiskDisable(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	nodeName := args[0]
 	diskID := args[1]
 
@@ -402,7 +459,7 @@ func runNodeDiskDisable(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := c.Nodes().DisableDiskScheduling(nodeName, diskID); err != nil {
+	if err := c.Nodes().DisableDiskScheduling(ctx, nodeName, diskID); err != nil {
 		return fmt.Errorf("failed to disable disk: %w", err)
 	}
 
@@ -413,6 +470,107 @@ func runNodeDiskDisable(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runNodeDiskEvict(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName := args[0]
+	diskID := args[1]
+	force, _ := cmd.Flags().GetBool("force")
+	remove, _ := cmd.Flags().GetBool("remove")
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	if remove && noWait {
+		return fmt.Errorf("--remove cannot be combined with --no-wait")
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	node, err := c.Nodes().Get(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+	disk, ok := node.Disks[diskID]
+	if !ok {
+		return fmt.Errorf("disk %s not found on node %s", diskID, nodeName)
+	}
+
+	volumes, err := c.Volumes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	affected := volumesWithReplicas(volumes, replicaOnDisk(node, diskID))
+
+	// Refuse to evict if any volume would be left without a healthy replica
+	var unsafe []string
+	for _, nv := range affected {
+		if nv.HealthyElsewhere == 0 {
+			unsafe = append(unsafe, nv.Volume.Name)
+		}
+	}
+	if len(unsafe) > 0 {
+		return fmt.Errorf(
+			"refusing to evict disk %s: no healthy replica elsewhere for volume(s): %s",
+			diskID,
+			strings.Join(unsafe, ", "),
+		)
+	}
+
+	fmt.Printf("Disk %s (%s) holds %d scheduled replica(s) of %d volume(s)\n",
+		diskID, disk.Path, len(disk.ScheduledReplica), len(affected))
+
+	if dryRun {
+		fmt.Printf("Dry run: would disable scheduling and request eviction on disk %s\n", diskID)
+		if remove {
+			fmt.Printf("Dry run: would remove disk %s from node %s\n", diskID, nodeName)
+		}
+		return nil
+	}
+
+	if !force &&
+		!utils.Confirm(
+			fmt.Sprintf("Evict all replicas from disk %s on node %s?", diskID, nodeName),
+		) {
+		fmt.Println("Eviction cancelled")
+		return nil
+	}
+
+	if err := drainDisk(ctx, c.Nodes(), nodeName, diskID); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Scheduling disabled and eviction requested for disk %s on node %s\n",
+		diskID, nodeName)
+
+	if noWait {
+		return nil
+	}
+
+	affectedNames := make([]string, 0, len(affected))
+	for _, nv := range affected {
+		affectedNames = append(affectedNames, nv.Volume.Name)
+	}
+
+	if err := waitForEviction(ctx, c, nodeName, diskID, affectedNames, interval, waitTimeout); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Disk %s on node %s is empty\n", diskID, nodeName)
+
+	if remove {
+		err := c.Nodes().RemoveDisk(ctx, nodeName, diskID, client.DiskRemoveOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to remove disk: %w", err)
+		}
+		fmt.Printf("✓ Disk %s removed from node %s\n", diskID, nodeName)
+	}
+
+	return nil
+}
+
 // Helper functions for printing
 
 func printNodesTable(nodes []client.Node) error {
@@ -526,12 +684,5 @@ func printNodeDetails(node *client.Node) error {
 }
 
 func getNodeStatus(node client.Node) string {
-	// Check if node is ready based on conditions
-	if readyCondition, exists := node.Conditions["Ready"]; exists {
-		if readyCondition.Status == "True" {
-			return "Ready"
-		}
-		return "NotReady"
-	}
-	return "Unknown"
+	return health.NodeStatus(node)
 }
@@ -0,0 +1,341 @@
+// cmd/node_drain.go
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/metrics"
+	"github.com/pascal71/lhcli/pkg/utils"
+)
+
+var nodeDrainCmd = &cobra.Command{
+	Use:   "drain [node-name]",
+	Short: "Safely drain all replicas from a node",
+	Long: `Disable scheduling on a node, request eviction of its replicas and wait until
+every replica has been moved away and all affected volumes are healthy again.
+
+The drain is refused if any volume with a replica on the node has no healthy
+replica on another node, since evicting it could leave the volume without data.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNodeDrain,
+}
+
+var nodeUndrainCmd = &cobra.Command{
+	Use:   "undrain [node-name]",
+	Short: "Reverse a node drain",
+	Long:  `Cancel the eviction request on a node and enable scheduling again.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runNodeUndrain,
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeDrainCmd)
+	nodeCmd.AddCommand(nodeUndrainCmd)
+
+	// Node drain flags
+	nodeDrainCmd.Flags().Bool("force", false, "Drain without confirmation")
+	nodeDrainCmd.Flags().Bool("no-wait", false, "Request eviction and return without waiting")
+	nodeDrainCmd.Flags().Duration("interval", 5*time.Second, "Polling interval while waiting")
+	nodeDrainCmd.Flags().
+		Duration("wait-timeout", 30*time.Minute, "Maximum time to wait for the drain to finish")
+}
+
+// nodeVolume describes a volume that has at least one replica on a given node or disk
+type nodeVolume struct {
+	Volume           client.Volume
+	LocalReplicas    []client.Replica // replicas on the node
+	HealthyElsewhere int              // usable replicas on other nodes
+}
+
+func runNodeDrain(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName := args[0]
+	force, _ := cmd.Flags().GetBool("force")
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.Nodes().Get(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+
+	volumes, err := c.Volumes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	affected := volumesOnNode(volumes, nodeName)
+
+	// Refuse to drain if any volume would be left without a healthy replica
+	var unsafe []string
+	for _, nv := range affected {
+		if nv.HealthyElsewhere == 0 {
+			unsafe = append(unsafe, nv.Volume.Name)
+		}
+	}
+	if len(unsafe) > 0 {
+		return fmt.Errorf(
+			"refusing to drain node %s: no healthy replica on another node for volume(s): %s",
+			nodeName,
+			strings.Join(unsafe, ", "),
+		)
+	}
+
+	fmt.Printf("Node %s holds replicas of %d volume(s)\n", nodeName, len(affected))
+	for _, nv := range affected {
+		fmt.Printf("  %s: %d local replica(s), %d healthy elsewhere\n",
+			nv.Volume.Name, len(nv.LocalReplicas), nv.HealthyElsewhere)
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run: would disable scheduling and request eviction on node %s\n", nodeName)
+		return nil
+	}
+
+	if !force &&
+		!utils.Confirm(fmt.Sprintf("Drain all replicas from node %s?", nodeName)) {
+		fmt.Println("Drain cancelled")
+		return nil
+	}
+
+	if err := drainNode(ctx, c.Nodes(), nodeName); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Scheduling disabled and eviction requested for node %s\n", nodeName)
+
+	if noWait {
+		return nil
+	}
+
+	affectedNames := make([]string, 0, len(affected))
+	for _, nv := range affected {
+		affectedNames = append(affectedNames, nv.Volume.Name)
+	}
+
+	if err := waitForEviction(ctx, c, nodeName, "", affectedNames, interval, waitTimeout); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Node %s drained\n", nodeName)
+	return nil
+}
+
+func runNodeUndrain(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName := args[0]
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	update := &client.NodeUpdate{
+		AllowScheduling:   &[]bool{true}[0],
+		EvictionRequested: &[]bool{false}[0],
+	}
+	if _, err := c.Nodes().Update(ctx, nodeName, update); err != nil {
+		return fmt.Errorf("failed to undrain node: %w", err)
+	}
+
+	fmt.Printf("✓ Eviction cancelled and scheduling enabled on node %s\n", nodeName)
+	return nil
+}
+
+// waitForEviction polls until the node (or a single disk on it when diskID is
+// set) has no scheduled replicas left and every affected volume is healthy
+// again, printing per-volume progress
+func waitForEviction(
+	ctx gocontext.Context,
+	c *client.Client,
+	nodeName, diskID string,
+	volumeNames []string,
+	interval, timeout time.Duration,
+) error {
+	deadline := time.Now().Add(timeout)
+	lastStatus := make(map[string]string)
+
+	for {
+		node, err := c.Nodes().Get(ctx, nodeName)
+		if err != nil {
+			return fmt.Errorf("failed to get node: %w", err)
+		}
+
+		remaining := 0
+		for id, disk := range node.Disks {
+			if diskID == "" || id == diskID {
+				remaining += len(disk.ScheduledReplica)
+			}
+		}
+
+		volumes, err := c.Volumes().List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list volumes: %w", err)
+		}
+		byName := make(map[string]client.Volume, len(volumes))
+		for _, v := range volumes {
+			byName[v.Name] = v
+		}
+
+		onTarget := replicaOnNode(nodeName)
+		if diskID != "" {
+			onTarget = replicaOnDisk(node, diskID)
+		}
+
+		settled := true
+		for _, name := range volumeNames {
+			volume, ok := byName[name]
+			if !ok {
+				// Volume was deleted while evicting
+				continue
+			}
+
+			local := 0
+			for _, r := range volume.Replicas {
+				if onTarget(r) {
+					local++
+				}
+			}
+
+			done := local == 0 && isVolumeSettled(volume)
+			if !done {
+				settled = false
+			}
+
+			status := fmt.Sprintf("%d replica(s) left to move, state %s, robustness %s",
+				local, getVolumeState(volume), volume.Robustness)
+			if done {
+				status = "done"
+			}
+			if lastStatus[name] != status {
+				fmt.Printf("  %s: %s\n", name, status)
+				lastStatus[name] = status
+			}
+		}
+
+		if remaining == 0 && settled {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"timed out after %s waiting for eviction (%d replica(s) remaining)",
+				timeout, remaining,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// drainNode disables scheduling on a node and then requests eviction of its
+// replicas, so that evicted replicas are not scheduled back onto the node
+func drainNode(ctx gocontext.Context, nodes client.NodeInterface, nodeName string) error {
+	if err := nodes.DisableScheduling(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to disable scheduling: %w", err)
+	}
+	if err := nodes.EvictNode(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to evict node: %w", err)
+	}
+	return nil
+}
+
+// drainDisk disables scheduling on a disk and then requests eviction of its
+// replicas, so that evicted replicas are not scheduled back onto the disk
+func drainDisk(ctx gocontext.Context, nodes client.NodeInterface, nodeName, diskID string) error {
+	if err := nodes.DisableDiskScheduling(ctx, nodeName, diskID); err != nil {
+		return fmt.Errorf("failed to disable disk: %w", err)
+	}
+	if err := nodes.EvictDisk(ctx, nodeName, diskID); err != nil {
+		return fmt.Errorf("failed to evict disk: %w", err)
+	}
+	return nil
+}
+
+// volumesOnNode returns the volumes that have at least one replica on the node,
+// together with the number of usable replicas they have on other nodes
+func volumesOnNode(volumes []client.Volume, nodeName string) []nodeVolume {
+	return volumesWithReplicas(volumes, replicaOnNode(nodeName))
+}
+
+// volumesWithReplicas returns the volumes that have at least one replica
+// matching onTarget, together with the number of usable replicas elsewhere
+func volumesWithReplicas(
+	volumes []client.Volume,
+	onTarget func(client.Replica) bool,
+) []nodeVolume {
+	var result []nodeVolume
+	for _, volume := range volumes {
+		nv := nodeVolume{Volume: volume}
+		for _, replica := range volume.Replicas {
+			if onTarget(replica) {
+				nv.LocalReplicas = append(nv.LocalReplicas, replica)
+			} else if isReplicaUsable(volume, replica) {
+				nv.HealthyElsewhere++
+			}
+		}
+		if len(nv.LocalReplicas) > 0 {
+			result = append(result, nv)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Volume.Name < result[j].Volume.Name
+	})
+	return result
+}
+
+// isReplicaUsable reports whether a replica can serve its volume. Replicas of
+// detached volumes are stopped, so only a failure rules them out.
+func isReplicaUsable(volume client.Volume, replica client.Replica) bool {
+	if strings.EqualFold(volume.State, "detached") {
+		return replica.FailedAt == ""
+	}
+	return metrics.IsReplicaHealthy(replica)
+}
+
+// replicaOnNode matches replicas placed on the node
+func replicaOnNode(nodeName string) func(client.Replica) bool {
+	return func(r client.Replica) bool {
+		return r.NodeID == nodeName
+	}
+}
+
+// replicaOnDisk matches replicas placed on a disk of the node. Replicas
+// reference disks by UUID, while the node keys its disks by name.
+func replicaOnDisk(node *client.Node, diskID string) func(client.Replica) bool {
+	diskUUID := node.Disks[diskID].DiskUUID
+	return func(r client.Replica) bool {
+		if r.NodeID != node.Name {
+			return false
+		}
+		return r.DiskID == diskID || (diskUUID != "" && r.DiskID == diskUUID)
+	}
+}
+
+// isVolumeSettled reports whether a volume no longer needs attention after
+// its replicas have been moved
+func isVolumeSettled(volume client.Volume) bool {
+	if strings.EqualFold(volume.Robustness, "healthy") {
+		return true
+	}
+	// Detached volumes report an unknown robustness
+	return strings.EqualFold(volume.State, "detached")
+}
@@ -0,0 +1,230 @@
+// cmd/node_preflight.go
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+)
+
+var nodePreflightCmd = &cobra.Command{
+	Use:   "preflight [node-name]",
+	Short: "Check whether a node can be rebooted safely",
+	Long: `Check whether a node can be taken down for maintenance (e.g. a kubelet reboot).
+
+The check reports volumes attached to the node, volumes that would become
+degraded or faulted if the replicas on the node went away, and replicas that
+are currently rebuilding to or from the node.
+
+Exit codes:
+  0  GO     - the node can be rebooted
+  2  NO-GO  - rebooting the node now would disrupt volumes
+  1         - the check itself failed`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNodePreflight,
+}
+
+func init() {
+	nodeCmd.AddCommand(nodePreflightCmd)
+
+	// Node preflight flags
+	nodePreflightCmd.Flags().Bool("strict", false, "Treat warnings (e.g. degraded volumes) as NO-GO")
+}
+
+// Preflight severities and verdicts
+const (
+	preflightNoGo = "NO-GO"
+	preflightWarn = "WARN"
+	preflightGo   = "GO"
+)
+
+// preflightFinding is a single reason contributing to the verdict
+type preflightFinding struct {
+	Severity string `json:"severity"`
+	Volume   string `json:"volume"`
+	Message  string `json:"message"`
+}
+
+// preflightResult is the outcome of a node preflight check
+type preflightResult struct {
+	Node     string             `json:"node"`
+	Verdict  string             `json:"verdict"`
+	Findings []preflightFinding `json:"findings"`
+}
+
+func runNodePreflight(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName := args[0]
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.Nodes().Get(ctx, nodeName); err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+
+	volumes, err := c.Volumes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	engines, err := c.Engines().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list engines: %w", err)
+	}
+
+	result := nodePreflight(nodeName, volumes, engines, strict)
+
+	switch output {
+	case "json":
+		err = formatter.NewJSONFormatter(true).Format(result)
+	case "yaml":
+		err = formatter.NewYAMLFormatter().Format(result)
+	default:
+		err = printPreflightResult(result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.Verdict == preflightNoGo {
+		return silentExit(cmd, 2, fmt.Sprintf("node %s is not safe to reboot", nodeName))
+	}
+	return nil
+}
+
+// nodePreflight evaluates whether the node can go down without disrupting volumes
+func nodePreflight(
+	nodeName string,
+	volumes []client.Volume,
+	engines []client.Engine,
+	strict bool,
+) *preflightResult {
+	result := &preflightResult{
+		Node:     nodeName,
+		Findings: []preflightFinding{},
+	}
+
+	// Volumes whose engine runs on the node lose their frontend on reboot
+	for _, engine := range engines {
+		if engine.NodeID == nodeName && engine.CurrentState == "running" {
+			result.Findings = append(result.Findings, preflightFinding{
+				Severity: preflightNoGo,
+				Volume:   engine.VolumeName,
+				Message:  "volume is attached to this node (engine running)",
+			})
+		}
+	}
+
+	// Volumes that would lose replicas
+	localReplicas := make(map[string]bool)
+	for _, nv := range volumesOnNode(volumes, nodeName) {
+		for _, replica := range nv.LocalReplicas {
+			localReplicas[replica.Name] = true
+		}
+
+		switch {
+		case nv.HealthyElsewhere == 0:
+			result.Findings = append(result.Findings, preflightFinding{
+				Severity: preflightNoGo,
+				Volume:   nv.Volume.Name,
+				Message:  "volume would become faulted: no healthy replica on another node",
+			})
+		case nv.HealthyElsewhere < nv.Volume.NumberOfReplicas:
+			result.Findings = append(result.Findings, preflightFinding{
+				Severity: preflightWarn,
+				Volume:   nv.Volume.Name,
+				Message: fmt.Sprintf(
+					"volume would become degraded: %d of %d replicas remain healthy",
+					nv.HealthyElsewhere, nv.Volume.NumberOfReplicas,
+				),
+			})
+		}
+	}
+
+	// Rebuilds that involve a replica on the node
+	for _, engine := range engines {
+		localAddresses := make(map[string]string) // address -> replica name
+		for replicaName, address := range engine.ReplicaAddressMap {
+			if localReplicas[replicaName] {
+				localAddresses[address] = replicaName
+			}
+		}
+
+		for address, rebuild := range engine.RebuildStatus {
+			if !rebuild.IsRebuilding {
+				continue
+			}
+			if replicaName, ok := localAddresses[rebuild.FromReplicaAddress]; ok {
+				result.Findings = append(result.Findings, preflightFinding{
+					Severity: preflightNoGo,
+					Volume:   engine.VolumeName,
+					Message: fmt.Sprintf(
+						"replica %s on this node is the source of a rebuild (%d%%)",
+						replicaName, rebuild.Progress,
+					),
+				})
+			}
+			if replicaName, ok := localAddresses[address]; ok {
+				result.Findings = append(result.Findings, preflightFinding{
+					Severity: preflightNoGo,
+					Volume:   engine.VolumeName,
+					Message: fmt.Sprintf(
+						"replica %s on this node is rebuilding (%d%%)",
+						replicaName, rebuild.Progress,
+					),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		if result.Findings[i].Severity != result.Findings[j].Severity {
+			return result.Findings[i].Severity == preflightNoGo
+		}
+		return result.Findings[i].Volume < result.Findings[j].Volume
+	})
+
+	result.Verdict = preflightGo
+	for _, finding := range result.Findings {
+		if finding.Severity == preflightNoGo || (strict && finding.Severity == preflightWarn) {
+			result.Verdict = preflightNoGo
+			break
+		}
+	}
+
+	return result
+}
+
+func printPreflightResult(result *preflightResult) error {
+	fmt.Printf("Preflight check for node %s\n\n", result.Node)
+
+	if len(result.Findings) == 0 {
+		fmt.Println("No volumes would be affected.")
+	} else {
+		headers := []string{"SEVERITY", "VOLUME", "REASON"}
+		table := formatter.NewTableFormatter(headers)
+		for _, finding := range result.Findings {
+			table.AddRow([]string{finding.Severity, finding.Volume, finding.Message})
+		}
+		if err := table.Format(nil); err != nil {
+			return err
+		}
+	}
+
+	verdictColor := color.New(color.FgGreen, color.Bold)
+	if result.Verdict == preflightNoGo {
+		verdictColor = color.New(color.FgRed, color.Bold)
+	}
+	fmt.Printf("\nVerdict: %s\n", verdictColor.Sprint(result.Verdict))
+	return nil
+}
@@ -0,0 +1,350 @@
+// cmd/node_preflight_test.go
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// replica returns a replica of a volume on a node in the given mode
+func replica(name, nodeID, mode string) client.Replica {
+	return client.Replica{Name: name, NodeID: nodeID, DiskID: "disk-1", Mode: mode}
+}
+
+func TestNodePreflight(t *testing.T) {
+	threeReplicas := client.Volume{
+		Name:             "vol-a",
+		NumberOfReplicas: 3,
+		Replicas: []client.Replica{
+			replica("vol-a-r-1", "node-1", "RW"),
+			replica("vol-a-r-2", "node-2", "RW"),
+			replica("vol-a-r-3", "node-3", "RW"),
+		},
+	}
+	onlyLocal := client.Volume{
+		Name:             "vol-b",
+		NumberOfReplicas: 2,
+		Replicas: []client.Replica{
+			replica("vol-b-r-1", "node-1", "RW"),
+			{Name: "vol-b-r-2", NodeID: "node-2", Mode: "ERR", FailedAt: "2024-01-01T00:00:00Z"},
+		},
+	}
+	detached := client.Volume{
+		Name:             "vol-d",
+		State:            "detached",
+		NumberOfReplicas: 3,
+		Replicas: []client.Replica{
+			replica("vol-d-r-1", "node-1", ""),
+			replica("vol-d-r-2", "node-2", "stopped"),
+			replica("vol-d-r-3", "node-3", "stopped"),
+		},
+	}
+	rebuildEngine := func(from, to string) client.Engine {
+		return client.Engine{
+			Name:         "vol-a-e-0",
+			VolumeName:   "vol-a",
+			NodeID:       "node-2",
+			CurrentState: "running",
+			ReplicaAddressMap: map[string]string{
+				"vol-a-r-1": "10.0.0.1:10000",
+				"vol-a-r-2": "10.0.0.2:10000",
+				"vol-a-r-3": "10.0.0.3:10000",
+			},
+			RebuildStatus: map[string]client.RebuildStatus{
+				to: {IsRebuilding: true, Progress: 40, FromReplicaAddress: from},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		volumes      []client.Volume
+		engines      []client.Engine
+		strict       bool
+		wantVerdict  string
+		wantFindings []preflightFinding
+	}{
+		{
+			name: "no volumes on the node",
+			volumes: []client.Volume{
+				{Name: "vol-c", Replicas: []client.Replica{replica("vol-c-r-1", "node-2", "RW")}},
+			},
+			wantVerdict: preflightGo,
+		},
+		{
+			name: "attached volume",
+			engines: []client.Engine{
+				{VolumeName: "vol-c", NodeID: "node-1", CurrentState: "running"},
+			},
+			wantVerdict: preflightNoGo,
+			wantFindings: []preflightFinding{{
+				Severity: preflightNoGo,
+				Volume:   "vol-c",
+				Message:  "volume is attached to this node (engine running)",
+			}},
+		},
+		{
+			name: "stopped engine",
+			engines: []client.Engine{
+				{VolumeName: "vol-c", NodeID: "node-1", CurrentState: "stopped"},
+			},
+			wantVerdict: preflightGo,
+		},
+		{
+			name:        "last healthy replica",
+			volumes:     []client.Volume{onlyLocal},
+			wantVerdict: preflightNoGo,
+			wantFindings: []preflightFinding{{
+				Severity: preflightNoGo,
+				Volume:   "vol-b",
+				Message:  "volume would become faulted: no healthy replica on another node",
+			}},
+		},
+		{
+			name:        "detached volume with stopped replicas elsewhere",
+			volumes:     []client.Volume{detached},
+			wantVerdict: preflightGo,
+			wantFindings: []preflightFinding{{
+				Severity: preflightWarn,
+				Volume:   "vol-d",
+				Message:  "volume would become degraded: 2 of 3 replicas remain healthy",
+			}},
+		},
+		{
+			name:        "volume would become degraded",
+			volumes:     []client.Volume{threeReplicas},
+			wantVerdict: preflightGo,
+			wantFindings: []preflightFinding{{
+				Severity: preflightWarn,
+				Volume:   "vol-a",
+				Message:  "volume would become degraded: 2 of 3 replicas remain healthy",
+			}},
+		},
+		{
+			name:        "volume would become degraded, strict",
+			volumes:     []client.Volume{threeReplicas},
+			strict:      true,
+			wantVerdict: preflightNoGo,
+			wantFindings: []preflightFinding{{
+				Severity: preflightWarn,
+				Volume:   "vol-a",
+				Message:  "volume would become degraded: 2 of 3 replicas remain healthy",
+			}},
+		},
+		{
+			name:        "rebuild from a replica on the node",
+			volumes:     []client.Volume{threeReplicas},
+			engines:     []client.Engine{rebuildEngine("10.0.0.1:10000", "10.0.0.3:10000")},
+			wantVerdict: preflightNoGo,
+			wantFindings: []preflightFinding{
+				{
+					Severity: preflightNoGo,
+					Volume:   "vol-a",
+					Message:  "replica vol-a-r-1 on this node is the source of a rebuild (40%)",
+				},
+				{
+					Severity: preflightWarn,
+					Volume:   "vol-a",
+					Message:  "volume would become degraded: 2 of 3 replicas remain healthy",
+				},
+			},
+		},
+		{
+			name:        "rebuild of a replica on the node",
+			volumes:     []client.Volume{threeReplicas},
+			engines:     []client.Engine{rebuildEngine("10.0.0.2:10000", "10.0.0.1:10000")},
+			wantVerdict: preflightNoGo,
+			wantFindings: []preflightFinding{
+				{
+					Severity: preflightNoGo,
+					Volume:   "vol-a",
+					Message:  "replica vol-a-r-1 on this node is rebuilding (40%)",
+				},
+				{
+					Severity: preflightWarn,
+					Volume:   "vol-a",
+					Message:  "volume would become degraded: 2 of 3 replicas remain healthy",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := nodePreflight("node-1", tt.volumes, tt.engines, tt.strict)
+
+			if result.Verdict != tt.wantVerdict {
+				t.Errorf("verdict = %s, want %s", result.Verdict, tt.wantVerdict)
+			}
+			if len(result.Findings) != len(tt.wantFindings) {
+				t.Fatalf("findings = %+v, want %+v", result.Findings, tt.wantFindings)
+			}
+			for i, finding := range result.Findings {
+				if finding != tt.wantFindings[i] {
+					t.Errorf("finding %d = %+v, want %+v", i, finding, tt.wantFindings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestVolumesWithReplicas(t *testing.T) {
+	volumes := []client.Volume{
+		{
+			Name: "vol-b",
+			Replicas: []client.Replica{
+				replica("vol-b-r-1", "node-1", "running"),
+				replica("vol-b-r-2", "node-2", "running"),
+				{
+					Name:     "vol-b-r-3",
+					NodeID:   "node-3",
+					Mode:     "running",
+					FailedAt: "2024-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			Name: "vol-a",
+			Replicas: []client.Replica{
+				{Name: "vol-a-r-1", NodeID: "node-1", DiskID: "uuid-2", Mode: "RW"},
+				replica("vol-a-r-2", "node-2", "WO"),
+			},
+		},
+		{
+			Name:     "vol-c",
+			Replicas: []client.Replica{replica("vol-c-r-1", "node-2", "RW")},
+		},
+		{
+			// Replicas of detached volumes are stopped but still usable
+			Name:  "vol-d",
+			State: "detached",
+			Replicas: []client.Replica{
+				replica("vol-d-r-1", "node-1", ""),
+				replica("vol-d-r-2", "node-2", ""),
+				replica("vol-d-r-3", "node-3", "stopped"),
+				{Name: "vol-d-r-4", NodeID: "node-3", FailedAt: "2024-01-01T00:00:00Z"},
+			},
+		},
+	}
+	node := &client.Node{
+		Name: "node-1",
+		Disks: map[string]client.Disk{
+			"disk-1": {DiskUUID: "uuid-1"},
+			"disk-2": {DiskUUID: "uuid-2"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		onTarget    func(client.Replica) bool
+		wantVolumes []string
+		wantHealthy []int
+	}{
+		{
+			name:        "node",
+			onTarget:    replicaOnNode("node-1"),
+			wantVolumes: []string{"vol-a", "vol-b", "vol-d"},
+			wantHealthy: []int{0, 1, 2},
+		},
+		{
+			name:        "disk by name",
+			onTarget:    replicaOnDisk(node, "disk-1"),
+			wantVolumes: []string{"vol-b", "vol-d"},
+			wantHealthy: []int{1, 2},
+		},
+		{
+			name:        "disk by UUID",
+			onTarget:    replicaOnDisk(node, "disk-2"),
+			wantVolumes: []string{"vol-a"},
+			wantHealthy: []int{0},
+		},
+		{
+			name:     "node without replicas",
+			onTarget: replicaOnNode("node-4"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := volumesWithReplicas(volumes, tt.onTarget)
+
+			if len(result) != len(tt.wantVolumes) {
+				t.Fatalf("got %d volumes, want %v", len(result), tt.wantVolumes)
+			}
+			for i, nv := range result {
+				if nv.Volume.Name != tt.wantVolumes[i] {
+					t.Errorf("volume %d = %s, want %s", i, nv.Volume.Name, tt.wantVolumes[i])
+				}
+				if nv.HealthyElsewhere != tt.wantHealthy[i] {
+					t.Errorf("%s: healthy elsewhere = %d, want %d",
+						nv.Volume.Name, nv.HealthyElsewhere, tt.wantHealthy[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNodePreflightExitCode(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"metadata.yaml": "namespace: longhorn-system\n",
+		"longhorn/nodes.yaml": `apiVersion: longhorn.io/v1beta2
+kind: NodeList
+items:
+- apiVersion: longhorn.io/v1beta2
+  kind: Node
+  metadata: {name: node-1, namespace: longhorn-system}
+- apiVersion: longhorn.io/v1beta2
+  kind: Node
+  metadata: {name: node-2, namespace: longhorn-system}
+`,
+		"longhorn/engines.yaml": `apiVersion: longhorn.io/v1beta2
+kind: EngineList
+items:
+- apiVersion: longhorn.io/v1beta2
+  kind: Engine
+  metadata: {name: vol-a-e-0, namespace: longhorn-system}
+  spec: {volumeName: vol-a, nodeID: node-1}
+  status: {currentState: running}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	savedBundle, savedOutput := fromBundle, output
+	t.Cleanup(func() { fromBundle, output = savedBundle, savedOutput })
+	fromBundle, output = dir, "json"
+
+	tests := []struct {
+		node     string
+		wantCode int
+	}{
+		{node: "node-1", wantCode: 2},
+		{node: "node-2", wantCode: 0},
+		{node: "node-3", wantCode: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.node, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("strict", false, "")
+
+			err := runNodePreflight(cmd, []string{tt.node})
+			if code := ExitCode(err); code != tt.wantCode {
+				t.Errorf("exit code = %d (%v), want %d", code, err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// cmd/orphan.go
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/orphan"
+	"github.com/pascal71/lhcli/pkg/utils"
+)
+
+var orphanCmd = &cobra.Command{
+	Use:   "orphan",
+	Short: "Manage orphaned data",
+	Long: `Manage data on Longhorn disks that no longer belongs to a volume.
+
+Orphaned data is found in three ways:
+  data        data directories reported by Longhorn orphan resources
+  replica     replicas of volumes that no longer exist
+  scheduled   disk space scheduled for replicas that no longer exist; Longhorn
+              releases it by itself, so it is reported but never deleted`,
+}
+
+var orphanListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List orphaned data",
+	Long: `List orphaned data and the space that can be reclaimed per node and disk.
+
+Longhorn does not report the size of orphaned data directories, so their
+space is shown as unknown.`,
+	RunE: runOrphanList,
+}
+
+var orphanDeleteCmd = &cobra.Command{
+	Use:   "delete [name...]",
+	Short: "Delete orphaned data",
+	Long: `Delete orphaned data by name, or all of it with --all.
+
+Deletion is a dry run by default: the items that would be deleted and the
+space reclaimed per node and disk are shown, and nothing is changed until the
+command is repeated with --confirm. Deleting an orphan resource makes Longhorn
+remove the data from the disk; replicas of deleted volumes are deleted
+together with their data.`,
+	RunE: runOrphanDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(orphanCmd)
+	orphanCmd.AddCommand(orphanListCmd)
+	orphanCmd.AddCommand(orphanDeleteCmd)
+
+	// Orphan list flags
+	orphanListCmd.Flags().String("node", "", "Only show orphaned data on this node")
+
+	// Orphan delete flags
+	orphanDeleteCmd.Flags().String("node", "", "Only delete orphaned data on this node")
+	orphanDeleteCmd.Flags().Bool("all", false, "Delete all orphaned data")
+	orphanDeleteCmd.Flags().Bool("confirm", false, "Delete instead of showing what would be deleted")
+}
+
+func runOrphanList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName, _ := cmd.Flags().GetString("node")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	report, err := findOrphans(ctx, c, nodeName)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(report)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(report)
+	default:
+		if len(report.Items) == 0 {
+			fmt.Println("No orphaned data found")
+			return nil
+		}
+		return printOrphanReport(report)
+	}
+}
+
+func runOrphanDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName, _ := cmd.Flags().GetString("node")
+	all, _ := cmd.Flags().GetBool("all")
+	confirm, _ := cmd.Flags().GetBool("confirm")
+
+	if len(args) == 0 && !all {
+		return fmt.Errorf("specify the orphaned data to delete or use --all")
+	}
+	if len(args) > 0 && all {
+		return fmt.Errorf("names cannot be combined with --all")
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	found, err := findOrphans(ctx, c, nodeName)
+	if err != nil {
+		return err
+	}
+
+	// Select the items to delete
+	byName := make(map[string]orphan.Item, len(found.Items))
+	for _, item := range found.Items {
+		byName[item.Name] = item
+	}
+	var selected []orphan.Item
+	if all {
+		for _, item := range found.Items {
+			if item.Deletable {
+				selected = append(selected, item)
+			}
+		}
+	} else {
+		for _, name := range args {
+			item, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("no orphaned data named %s", name)
+			}
+			if !item.Deletable {
+				return fmt.Errorf(
+					"%s is space scheduled for a missing replica and is released by Longhorn",
+					name,
+				)
+			}
+			selected = append(selected, item)
+		}
+	}
+
+	if len(selected) == 0 {
+		fmt.Println("No orphaned data to delete")
+		return nil
+	}
+
+	plan := orphan.Summarize(selected)
+	if err := printOrphanReport(plan); err != nil {
+		return err
+	}
+
+	if !confirm || dryRun {
+		fmt.Printf("\nDry run: %d item(s) would be deleted. Re-run with --confirm to delete.\n",
+			len(selected))
+		return nil
+	}
+
+	fmt.Println()
+	failed := 0
+	for _, item := range selected {
+		var err error
+		switch item.Kind {
+		case orphan.KindData:
+			err = c.Orphans().Delete(ctx, item.Name)
+		case orphan.KindReplica:
+			err = c.Replicas().Delete(ctx, item.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ Deleted %s %s on node %s\n", item.Kind, item.Name, item.Node)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d item(s)", failed, len(selected))
+	}
+	return nil
+}
+
+// findOrphans collects the orphaned data, optionally on a single node
+func findOrphans(ctx gocontext.Context, c *client.Client, nodeName string) (*orphan.Report, error) {
+	orphans, err := c.Orphans().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orphans: %w", err)
+	}
+	nodes, err := c.Nodes().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	replicas, err := c.Replicas().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicas: %w", err)
+	}
+	volumes, err := c.Volumes().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	report := orphan.Find(orphans, nodes, replicas, volumes)
+	if nodeName == "" {
+		return report, nil
+	}
+
+	var items []orphan.Item
+	for _, item := range report.Items {
+		if item.Node == nodeName {
+			items = append(items, item)
+		}
+	}
+	return orphan.Summarize(items), nil
+}
+
+func printOrphanReport(report *orphan.Report) error {
+	headers := []string{"KIND", "NAME", "NODE", "DISK", "VOLUME", "SIZE"}
+	table := formatter.NewTableFormatter(headers)
+	for _, item := range report.Items {
+		volume := item.Volume
+		if volume == "" {
+			volume = "-"
+		}
+		table.AddRow([]string{
+			item.Kind,
+			item.Name,
+			item.Node,
+			shortenDiskID(item.Disk),
+			volume,
+			formatOrphanSize(item.Size, item.SizeKnown),
+		})
+	}
+	if err := table.Format(nil); err != nil {
+		return err
+	}
+
+	if len(report.Disks) == 0 {
+		return nil
+	}
+
+	fmt.Println("\nReclaimable space:")
+	table = formatter.NewTableFormatter([]string{"NODE", "DISK", "PATH", "ITEMS", "SIZE"})
+	for _, disk := range report.Disks {
+		size := utils.FormatSize(disk.Size)
+		if disk.UnknownSize > 0 {
+			size = fmt.Sprintf("%s + %d of unknown size", size, disk.UnknownSize)
+		}
+		table.AddRow([]string{
+			disk.Node,
+			shortenDiskID(disk.Disk),
+			disk.DiskPath,
+			fmt.Sprintf("%d", disk.Items),
+			size,
+		})
+	}
+	if err := table.Format(nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nTotal: %s\n", utils.FormatSize(report.Size))
+	return nil
+}
+
+func formatOrphanSize(size int64, known bool) string {
+	if !known {
+		return "unknown"
+	}
+	return utils.FormatSize(size)
+}
@@ -53,7 +53,9 @@ func init() {
 	replicaCmd.AddCommand(replicaDeleteCmd)
 
 	// Replica list flags
-	replicaListCmd.Flags().StringVar(&volumeFilter, "volume", "", "Filter replicas by volume name")
+	replicaListCmd.Flags().StringVar(&volumeFilter, "volume", "",
+		"Filter replicas by volume name or pvc/<namespace>/<name>")
+	addPVCFlag(replicaListCmd)
 	replicaListCmd.Flags().StringVar(&nodeFilter, "node", "", "Filter replicas by node")
 	replicaListCmd.Flags().
 		Bool("full-ids", false, "Show full replica names and disk IDs without abbreviation")
@@ -67,12 +69,24 @@ func init() {
 }
 
 func runReplicaList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	ref, err := volumeRef(cmd, volumeFilter)
+	if err != nil {
+		return err
+	}
+
 	c, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	replicas, err := c.Replicas().List()
+	volumeName, err := resolveVolumeName(ctx, c, ref)
+	if err != nil {
+		return err
+	}
+
+	replicas, err := c.Replicas().List(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list replicas: %w", err)
 	}
@@ -80,7 +94,7 @@ func runReplicaList(cmd *cobra.Command, args []string) error {
 	// Apply filters
 	var filteredReplicas []client.Replica
 	for _, replica := range replicas {
-		if volumeFilter != "" && replica.VolumeName != volumeFilter {
+		if volumeName != "" && replica.VolumeName != volumeName {
 			continue
 		}
 		if nodeFilter != "" && replica.NodeID != nodeFilter {
@@ -106,6 +120,8 @@ func runReplicaList(cmd *cobra.Command, args []string) error {
 }
 
 func runReplicaGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	replicaName := args[0]
 
 	c, err := getClient()
@@ -113,7 +129,7 @@ func runReplicaGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	replica, err := c.Replicas().Get(replicaName)
+	replica, err := c.Replicas().Get(ctx, replicaName)
 	if err != nil {
 		return fmt.Errorf("failed to get replica: %w", err)
 	}
@@ -128,11 +144,18 @@ func runReplicaGet(cmd *cobra.Command, args []string) error {
 	case "yaml":
 		return formatter.NewYAMLFormatter().Format(replica)
 	default:
-		return printReplicaDetails(replica, showFullIDs)
+		var im *client.InstanceManager
+		if replica.InstanceManager != "" {
+			// Best effort: the link is informational only
+			im, _ = c.InstanceManagers().Get(ctx, replica.InstanceManager)
+		}
+		return printReplicaDetails(replica, im, showFullIDs)
 	}
 }
 
 func runReplicaDelete(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
 	replicaName := args[0]
 	force, _ := cmd.Flags().GetBool("force")
 
@@ -148,7 +171,7 @@ func runReplicaDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get replica details first to show what we're deleting
-	replica, err := c.Replicas().Get(replicaName)
+	replica, err := c.Replicas().Get(ctx, replicaName)
 	if err != nil {
 		return fmt.Errorf("failed to get replica details: %w", err)
 	}
@@ -156,7 +179,7 @@ func runReplicaDelete(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Deleting replica %s from volume %s on node %s...\n",
 		replicaName, replica.VolumeName, replica.NodeID)
 
-	if err := c.Replicas().Delete(replicaName); err != nil {
+	if err := c.Replicas().Delete(ctx, replicaName); err != nil {
 		return fmt.Errorf("failed to delete replica: %w", err)
 	}
 
@@ -276,7 +299,11 @@ func printReplicasWide(replicas []client.Replica, showFullIDs bool) error {
 	return formatter.Format(nil)
 }
 
-func printReplicaDetails(replica *client.Replica, showFullIDs bool) error {
+func printReplicaDetails(
+	replica *client.Replica,
+	im *client.InstanceManager,
+	showFullIDs bool,
+) error {
 	fmt.Printf("Name:              %s\n", replica.Name)
 	fmt.Printf("Volume:            %s\n", replica.VolumeName)
 	fmt.Printf("Node:              %s\n", replica.NodeID)
@@ -317,7 +344,12 @@ func printReplicaDetails(replica *client.Replica, showFullIDs bool) error {
 	}
 
 	if replica.InstanceManager != "" {
-		fmt.Printf("Instance Manager:  %s\n", replica.InstanceManager)
+		if im != nil {
+			fmt.Printf("Instance Manager:  %s (%s on %s)\n",
+				replica.InstanceManager, im.CurrentState, im.NodeID)
+		} else {
+			fmt.Printf("Instance Manager:  %s\n", replica.InstanceManager)
+		}
 	}
 
 	if replica.Image != "" {
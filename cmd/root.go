@@ -1,32 +1,83 @@
 package cmd
 
 import (
+    gocontext "context"
     "fmt"
     "os"
+    "os/signal"
+    "syscall"
+    "time"
     
     "github.com/spf13/cobra"
     "github.com/spf13/viper"
+
+    "github.com/pascal71/lhcli/pkg/client"
 )
 
 var (
-    cfgFile   string
-    namespace string
-    output    string
-    verbose   bool
-    quiet     bool
-    dryRun    bool
-    context   string
+    cfgFile    string
+    namespace  string
+    output     string
+    verbose    bool
+    quiet      bool
+    dryRun     bool
+    context    string
+    fromBundle string
+
+    conflictRetries int
+    timeout         time.Duration
+
+    // stopTimeout releases the --timeout context once the command returns
+    stopTimeout gocontext.CancelFunc = func() {}
 )
 
 var rootCmd = &cobra.Command{
     Use:   "lhcli",
     Short: "Longhorn CLI - A command-line interface for Longhorn",
     Long: `lhcli is a comprehensive CLI tool for managing Longhorn storage system.
-It provides functionality equivalent to the Longhorn WebUI and more.`,
+It provides functionality equivalent to the Longhorn WebUI and more.
+
+Exit codes:
+  0  success
+  1  the command failed
+  2  a check failed (health check, node preflight, volume create --simulate)
+  3  a resource was not found
+  4  a resource already exists
+  5  a resource was changed concurrently (conflict)
+  6  access was denied
+  7  the cluster or Longhorn manager is unavailable`,
+    PersistentPreRunE: applyTimeout,
 }
 
 func Execute() error {
-    return rootCmd.Execute()
+    ctx, stop := signalContext()
+    defer stop()
+
+    err := rootCmd.ExecuteContext(ctx)
+    stopTimeout()
+    if hint := errorHint(err); hint != "" {
+        fmt.Fprintln(os.Stderr, "Hint:", hint)
+    }
+    return err
+}
+
+// signalContext returns a context that is cancelled on Ctrl-C or SIGTERM
+func signalContext() (gocontext.Context, gocontext.CancelFunc) {
+    return signal.NotifyContext(gocontext.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// applyTimeout bounds the whole command, including waits and retries, by
+// --timeout
+func applyTimeout(cmd *cobra.Command, args []string) error {
+    if timeout < 0 {
+        return fmt.Errorf("--timeout must not be negative")
+    }
+    if timeout > 0 {
+        ctx, cancel := gocontext.WithTimeout(cmd.Context(), timeout)
+        cmd.SetContext(ctx)
+        stopTimeout = cancel
+    }
+    return nil
 }
 
 func init() {
@@ -40,6 +91,9 @@ func init() {
     rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
     rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Minimal output")
     rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Preview actions without executing")
+    rootCmd.PersistentFlags().StringVar(&fromBundle, "from-bundle", "", "Read resources from a support bundle instead of a cluster (read-only)")
+    rootCmd.PersistentFlags().IntVar(&conflictRetries, "conflict-retries", client.DefaultConflictRetries, "How often to retry an update that conflicts with a concurrent change")
+    rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Maximum time the command may run, including retries and waits (default: no limit)")
 }
 
 func initConfig() {
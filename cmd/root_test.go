@@ -0,0 +1,48 @@
+// cmd/root_test.go
+package cmd
+
+import (
+	gocontext "context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyTimeout(t *testing.T) {
+	saved, savedStop := timeout, stopTimeout
+	t.Cleanup(func() { timeout, stopTimeout = saved, savedStop })
+
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantErr      bool
+		wantDeadline bool
+	}{
+		{name: "no limit"},
+		{name: "limit", timeout: time.Minute, wantDeadline: true},
+		{name: "negative", timeout: -time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout = tt.timeout
+			cmd := &cobra.Command{}
+			cmd.SetContext(gocontext.Background())
+
+			err := applyTimeout(cmd, nil)
+			defer stopTimeout()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyTimeout() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			deadline, ok := cmd.Context().Deadline()
+			if ok != tt.wantDeadline {
+				t.Fatalf("deadline set = %v, want %v", ok, tt.wantDeadline)
+			}
+			if ok && time.Until(deadline) > tt.timeout {
+				t.Errorf("deadline in %s, want at most %s", time.Until(deadline), tt.timeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// cmd/serve.go
+package cmd
+
+import (
+	"bytes"
+	gocontext "context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/metrics"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run lhcli as a long-lived service",
+	Long:  `Run lhcli as a long-lived service, such as a metrics exporter.`,
+}
+
+var serveMetricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Serve Prometheus metrics",
+	Long: `Serve Prometheus metrics derived from the Longhorn volume, replica, node and
+backup resources.
+
+The resources are kept in memory through Kubernetes informers, so scrapes do
+not query the API server. Exported metrics include volume robustness and
+state, provisioned and actual size, replica placement per node and disk, disk
+usage and the age of the last completed backup of every volume. All metric
+names are prefixed with "lhcli_".`,
+	RunE: runServeMetrics,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveMetricsCmd)
+
+	// Serve metrics flags
+	serveMetricsCmd.Flags().String("listen", ":9500", "Address to listen on")
+	serveMetricsCmd.Flags().String("path", "/metrics", "HTTP path of the metrics endpoint")
+}
+
+func runServeMetrics(cmd *cobra.Command, args []string) error {
+	listen, _ := cmd.Flags().GetString("listen")
+	path, _ := cmd.Flags().GetString("path")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	resources, err := c.NewResourceCache()
+	if errors.Is(err, client.ErrWatchNotSupported) {
+		return fmt.Errorf("serve metrics requires a Kubernetes connection (kubeconfig)")
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	if !quiet {
+		fmt.Fprintln(os.Stderr, "Loading Longhorn resources...")
+	}
+	if err := resources.Start(ctx); err != nil {
+		return ignoreCanceled(fmt.Errorf("failed to load resources: %w", err))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		snapshot := metrics.Snapshot{
+			Volumes: resources.Volumes(),
+			Nodes:   resources.Nodes(),
+			Backups: resources.Backups(),
+			Time:    time.Now(),
+		}
+		if err := metrics.Write(&buf, snapshot); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(buf.Bytes())
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Serving metrics on %s%s\n", listen, path)
+	}
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("metrics server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := gocontext.WithTimeout(gocontext.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
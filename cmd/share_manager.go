@@ -0,0 +1,259 @@
+// cmd/share_manager.go
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/utils"
+)
+
+var shareManagerCmd = &cobra.Command{
+	Use:     "share-manager",
+	Aliases: []string{"sm"},
+	Short:   "Inspect and recover Longhorn share managers",
+	Long: `Inspect and recover Longhorn share managers, the NFS servers that export
+RWX volumes to their workloads. Each share manager is named after its volume.`,
+}
+
+var shareManagerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List share managers",
+	Long:  `List share managers with their volume, node, state and NFS endpoint.`,
+	RunE:  runShareManagerList,
+}
+
+var shareManagerGetCmd = &cobra.Command{
+	Use:   "get [share-manager-name]",
+	Short: "Get share manager details",
+	Long:  `Get detailed information about a share manager and the volume it exports.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runShareManagerGet,
+}
+
+var shareManagerRestartCmd = &cobra.Command{
+	Use:   "restart [share-manager-name]",
+	Short: "Restart a share manager",
+	Long: `Restart a share manager by deleting its pod. Longhorn recreates the pod and
+the workloads of the volume reconnect to the new NFS server. Use this when
+NFS mounts of an RWX volume hang; I/O on the volume stalls until the new
+share manager is running.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShareManagerRestart,
+}
+
+func init() {
+	rootCmd.AddCommand(shareManagerCmd)
+	shareManagerCmd.AddCommand(shareManagerListCmd)
+	shareManagerCmd.AddCommand(shareManagerGetCmd)
+	shareManagerCmd.AddCommand(shareManagerRestartCmd)
+
+	// Share manager list flags
+	shareManagerListCmd.Flags().String("node", "", "Filter share managers by node")
+
+	// Share manager restart flags
+	shareManagerRestartCmd.Flags().Bool("force", false, "Restart without confirmation")
+}
+
+func runShareManagerList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	nodeName, _ := cmd.Flags().GetString("node")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	managers, err := c.ShareManagers().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list share managers: %w", err)
+	}
+
+	filtered := make([]client.ShareManager, 0, len(managers))
+	for _, manager := range managers {
+		if nodeName != "" && manager.NodeID != nodeName {
+			continue
+		}
+		filtered = append(filtered, manager)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(filtered)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(filtered)
+	default:
+		return printShareManagersTable(filtered, output == "wide")
+	}
+}
+
+func runShareManagerGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	name := args[0]
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	manager, err := c.ShareManagers().Get(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get share manager: %w", err)
+	}
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(manager)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(manager)
+	default:
+		// Best effort: the volume state helps to tell a share manager
+		// problem from a volume problem
+		volume, _ := c.Volumes().Get(ctx, manager.Volume)
+		return printShareManagerDetails(manager, volume)
+	}
+}
+
+func runShareManagerRestart(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	name := args[0]
+	force, _ := cmd.Flags().GetBool("force")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	manager, err := c.ShareManagers().Get(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get share manager: %w", err)
+	}
+
+	if dryRun {
+		fmt.Printf("Would restart share manager %s of volume %s on node %s (dry run)\n",
+			manager.Name, manager.Volume, manager.NodeID)
+		return nil
+	}
+
+	if !force && !utils.Confirm(fmt.Sprintf(
+		"Restart share manager %s? NFS I/O on volume %s stalls until it is running again",
+		manager.Name, manager.Volume)) {
+		fmt.Println("Restart cancelled")
+		return nil
+	}
+
+	if err := c.ShareManagers().Restart(ctx, manager.Name); err != nil {
+		return fmt.Errorf("failed to restart share manager: %w", err)
+	}
+
+	fmt.Printf("✓ Share manager %s restarted\n", manager.Name)
+	fmt.Printf("Use 'lhcli share-manager get %s' to follow it back to running\n", manager.Name)
+
+	return nil
+}
+
+func printShareManagersTable(managers []client.ShareManager, wide bool) error {
+	headers := []string{"NAME", "VOLUME", "NODE", "STATE", "ENDPOINT"}
+	if wide {
+		headers = append(headers, "IMAGE")
+	}
+	table := formatter.NewTableFormatter(headers)
+
+	for _, manager := range managers {
+		row := []string{
+			manager.Name,
+			manager.Volume,
+			valueOrDash(manager.NodeID),
+			formatter.FormatStatus(manager.State, true),
+			valueOrDash(manager.Endpoint),
+		}
+		if wide {
+			row = append(row, manager.Image)
+		}
+		table.AddRow(row)
+	}
+
+	return table.Format(nil)
+}
+
+func printShareManagerDetails(manager *client.ShareManager, volume *client.Volume) error {
+	fmt.Printf("Name:              %s\n", manager.Name)
+	fmt.Printf("Volume:            %s\n", manager.Volume)
+	fmt.Printf("Node:              %s\n", valueOrDash(manager.NodeID))
+	fmt.Printf("State:             %s\n", formatter.FormatStatus(manager.State, true))
+	fmt.Printf("Endpoint:          %s\n", valueOrDash(manager.Endpoint))
+	fmt.Printf("Image:             %s\n", manager.Image)
+
+	if volume != nil {
+		fmt.Printf("\nVolume State:      %s\n", getVolumeState(*volume))
+		fmt.Printf("Volume Robustness: %s\n", volume.Robustness)
+		fmt.Printf("Access Mode:       %s\n", volume.AccessMode)
+	}
+
+	return nil
+}
+
+// volumeDetails is a volume with the share manager serving it, if it is an
+// RWX volume
+type volumeDetails struct {
+	client.Volume `json:",inline" yaml:",inline"`
+	ShareManager  *client.ShareManager `json:"shareManager,omitempty" yaml:"shareManager,omitempty"`
+}
+
+// volumeWithShareManager adds the share manager of an RWX volume to it. A
+// share manager that cannot be read is reported on stderr and left out.
+func volumeWithShareManager(
+	ctx gocontext.Context,
+	c *client.Client,
+	volume *client.Volume,
+) volumeDetails {
+	details := volumeDetails{Volume: *volume}
+	if volume.AccessMode != "rwx" {
+		return details
+	}
+
+	manager, err := c.ShareManagers().Get(ctx, volume.Name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: share manager unavailable: %v\n", err)
+		return details
+	}
+	details.ShareManager = manager
+	return details
+}
+
+// printVolumeShareManager prints the share manager of an RWX volume
+func printVolumeShareManager(ctx gocontext.Context, c *client.Client, volume *client.Volume) {
+	if volume.AccessMode != "rwx" {
+		return
+	}
+
+	fmt.Println("\nShare Manager:")
+	manager, err := c.ShareManagers().Get(ctx, volume.Name)
+	if err != nil {
+		fmt.Printf("  Unavailable: %v\n", err)
+		return
+	}
+	fmt.Printf("  Node:     %s\n", valueOrDash(manager.NodeID))
+	fmt.Printf("  State:    %s\n", formatter.FormatStatus(manager.State, true))
+	fmt.Printf("  Endpoint: %s\n", valueOrDash(manager.Endpoint))
+}
+
+// valueOrDash returns "-" for empty values in tables and details
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
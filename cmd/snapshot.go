@@ -13,28 +13,48 @@ var snapshotCmd = &cobra.Command{
 }
 
 var snapshotCreateCmd = &cobra.Command{
-    Use:   "create [volume-name]",
+    Use:   "create [volume-name | pvc/<namespace>/<name>]",
     Short: "Create a snapshot",
     Long:  `Create a snapshot of the specified volume.`,
-    Args:  cobra.ExactArgs(1),
-    Run: func(cmd *cobra.Command, args []string) {
-        volumeName := args[0]
+    Args:  volumeArgs,
+    RunE: func(cmd *cobra.Command, args []string) error {
+        ctx := cmd.Context()
+
+        ref, err := volumeArgRef(cmd, args)
+        if err != nil {
+            return err
+        }
+        volumeName, err := resolveVolumeName(ctx, nil, ref)
+        if err != nil {
+            return err
+        }
         name, _ := cmd.Flags().GetString("name")
         
         fmt.Printf("Creating snapshot %s for volume %s...\n", name, volumeName)
         // TODO: Implement snapshot create logic
+        return nil
     },
 }
 
 var snapshotListCmd = &cobra.Command{
-    Use:   "list [volume-name]",
+    Use:   "list [volume-name | pvc/<namespace>/<name>]",
     Short: "List snapshots",
     Long:  `List all snapshots for a specific volume.`,
-    Args:  cobra.ExactArgs(1),
-    Run: func(cmd *cobra.Command, args []string) {
-        volumeName := args[0]
+    Args:  volumeArgs,
+    RunE: func(cmd *cobra.Command, args []string) error {
+        ctx := cmd.Context()
+
+        ref, err := volumeArgRef(cmd, args)
+        if err != nil {
+            return err
+        }
+        volumeName, err := resolveVolumeName(ctx, nil, ref)
+        if err != nil {
+            return err
+        }
         fmt.Printf("Listing snapshots for volume %s...\n", volumeName)
         // TODO: Implement snapshot list logic
+        return nil
     },
 }
 
@@ -47,4 +67,8 @@ func init() {
     snapshotCreateCmd.Flags().String("name", "", "Snapshot name")
     snapshotCreateCmd.MarkFlagRequired("name")
     snapshotCreateCmd.Flags().StringToString("labels", nil, "Labels for the snapshot")
+    addPVCFlag(snapshotCreateCmd)
+    
+    // Snapshot list flags
+    addPVCFlag(snapshotListCmd)
 }
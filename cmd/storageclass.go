@@ -0,0 +1,386 @@
+// cmd/storageclass.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pascal71/lhcli/internal/validation"
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/formatter"
+)
+
+var storageClassCmd = &cobra.Command{
+	Use:     "storageclass",
+	Aliases: []string{"sc"},
+	Short:   "Inspect and generate Longhorn StorageClasses",
+	Long: `Inspect and generate Kubernetes StorageClasses that provision Longhorn volumes
+through the driver.longhorn.io CSI driver.
+
+Longhorn silently ignores StorageClass parameters it does not know and falls
+back to defaults for invalid values, so a typo only shows up as a volume
+with the wrong settings. The commands here validate the parameters.`,
+}
+
+var storageClassListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Longhorn StorageClasses",
+	Long: `List the StorageClasses using the Longhorn provisioner with their main
+parameters and the number of parameter problems found.`,
+	RunE: runStorageClassList,
+}
+
+var storageClassDescribeCmd = &cobra.Command{
+	Use:   "describe [storageclass-name]",
+	Short: "Describe a StorageClass and validate its parameters",
+	Long: `Show a StorageClass with its parameters decoded into readable form, and the
+problems found in them, such as unknown parameter names or invalid values.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStorageClassDescribe,
+}
+
+var storageClassGenerateCmd = &cobra.Command{
+	Use:   "generate [storageclass-name]",
+	Short: "Generate a Longhorn StorageClass",
+	Long: `Generate a Longhorn StorageClass from flags that mirror 'lhcli volume create'.
+The parameters are validated and the manifest is printed as YAML; with
+--apply the StorageClass is created in the cluster instead.
+
+Parameters without a dedicated flag can be set with --param key=value.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStorageClassGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(storageClassCmd)
+	storageClassCmd.AddCommand(storageClassListCmd)
+	storageClassCmd.AddCommand(storageClassDescribeCmd)
+	storageClassCmd.AddCommand(storageClassGenerateCmd)
+
+	// StorageClass generate flags
+	flags := storageClassGenerateCmd.Flags()
+	flags.Int("replicas", 3, "Number of replicas")
+	flags.String("data-locality", "", "Data locality (disabled|best-effort|strict-local)")
+	flags.StringSlice("node-selector", []string{}, "Node selector tags")
+	flags.StringSlice("disk-selector", []string{}, "Disk selector tags")
+	flags.StringSlice("recurring-jobs", []string{}, "Recurring jobs to apply to new volumes")
+	flags.StringSlice("recurring-job-groups", []string{}, "Recurring job groups to apply")
+	flags.Int("stale-replica-timeout", 0, "Minutes before a failed replica is removed (0 = default)")
+	flags.String("from-backup", "", "Backup URL new volumes are restored from")
+	flags.String("fs-type", "", "Filesystem of new volumes (ext4|xfs)")
+	flags.Bool("migratable", false, "Allow live migration of new volumes (RWX block volumes)")
+	flags.Bool("encrypted", false, "Encrypt new volumes")
+	flags.StringToString("param", nil, "Additional Longhorn parameters (key=value)")
+	flags.String("reclaim-policy", "Delete", "Reclaim policy (Delete|Retain)")
+	flags.String("binding-mode", "Immediate", "Volume binding mode (Immediate|WaitForFirstConsumer)")
+	flags.Bool("allow-expansion", true, "Allow volume expansion")
+	flags.Bool("default", false, "Mark as the default StorageClass of the cluster")
+	flags.Bool("apply", false, "Create the StorageClass in the cluster instead of printing it")
+}
+
+// storageClassReport is a StorageClass with the problems of its parameters
+type storageClassReport struct {
+	client.StorageClass `json:",inline" yaml:",inline"`
+	Problems            map[string]string `json:"problems,omitempty"`
+}
+
+func newStorageClassReport(class client.StorageClass) storageClassReport {
+	report := storageClassReport{StorageClass: class}
+	for key, err := range validation.ValidateStorageClassParameters(class.Parameters) {
+		if report.Problems == nil {
+			report.Problems = make(map[string]string)
+		}
+		report.Problems[key] = err.Error()
+	}
+	return report
+}
+
+func runStorageClassList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	classes, err := c.ListStorageClasses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list storage classes: %w", err)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+
+	reports := make([]storageClassReport, 0, len(classes))
+	for _, class := range classes {
+		reports = append(reports, newStorageClassReport(class))
+	}
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(reports)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(reports)
+	default:
+		return printStorageClassesTable(reports)
+	}
+}
+
+func runStorageClassDescribe(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	class, err := c.GetStorageClass(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get storage class: %w", err)
+	}
+	report := newStorageClassReport(*class)
+
+	switch output {
+	case "json":
+		return formatter.NewJSONFormatter(true).Format(report)
+	case "yaml":
+		return formatter.NewYAMLFormatter().Format(report)
+	default:
+		return printStorageClassDetails(report)
+	}
+}
+
+func runStorageClassGenerate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	flags := cmd.Flags()
+	replicas, _ := flags.GetInt("replicas")
+	dataLocality, _ := flags.GetString("data-locality")
+	nodeSelector, _ := flags.GetStringSlice("node-selector")
+	diskSelector, _ := flags.GetStringSlice("disk-selector")
+	recurringJobs, _ := flags.GetStringSlice("recurring-jobs")
+	recurringJobGroups, _ := flags.GetStringSlice("recurring-job-groups")
+	staleReplicaTimeout, _ := flags.GetInt("stale-replica-timeout")
+	fromBackup, _ := flags.GetString("from-backup")
+	fsType, _ := flags.GetString("fs-type")
+	extra, _ := flags.GetStringToString("param")
+	apply, _ := flags.GetBool("apply")
+
+	params := map[string]string{
+		"numberOfReplicas": fmt.Sprintf("%d", replicas),
+	}
+	if dataLocality != "" {
+		params["dataLocality"] = dataLocality
+	}
+	if len(nodeSelector) > 0 {
+		params["nodeSelector"] = strings.Join(nodeSelector, ",")
+	}
+	if len(diskSelector) > 0 {
+		params["diskSelector"] = strings.Join(diskSelector, ",")
+	}
+	if len(recurringJobs) > 0 || len(recurringJobGroups) > 0 {
+		selector := make([]validation.RecurringJobSelector, 0)
+		for _, job := range recurringJobs {
+			selector = append(selector, validation.RecurringJobSelector{Name: job})
+		}
+		for _, group := range recurringJobGroups {
+			selector = append(selector, validation.RecurringJobSelector{Name: group, IsGroup: true})
+		}
+		data, err := json.Marshal(selector)
+		if err != nil {
+			return fmt.Errorf("failed to encode recurring job selector: %w", err)
+		}
+		params["recurringJobSelector"] = string(data)
+	}
+	if staleReplicaTimeout > 0 {
+		params["staleReplicaTimeout"] = fmt.Sprintf("%d", staleReplicaTimeout)
+	}
+	if fromBackup != "" {
+		params["fromBackup"] = fromBackup
+	}
+	if fsType != "" {
+		params["fsType"] = fsType
+	}
+	if flags.Changed("migratable") {
+		migratable, _ := flags.GetBool("migratable")
+		params["migratable"] = fmt.Sprintf("%t", migratable)
+	}
+	if flags.Changed("encrypted") {
+		encrypted, _ := flags.GetBool("encrypted")
+		params["encrypted"] = fmt.Sprintf("%t", encrypted)
+	}
+	for key, value := range extra {
+		params[key] = value
+	}
+
+	if problems := validation.ValidateStorageClassParameters(params); len(problems) > 0 {
+		keys := sortedKeys(params, problems)
+		lines := make([]string, 0, len(keys))
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("  %s: %v", key, problems[key]))
+		}
+		return fmt.Errorf("invalid StorageClass parameters:\n%s", strings.Join(lines, "\n"))
+	}
+
+	class := &client.StorageClass{
+		Name:       args[0],
+		Parameters: params,
+	}
+	class.ReclaimPolicy, _ = flags.GetString("reclaim-policy")
+	class.VolumeBindingMode, _ = flags.GetString("binding-mode")
+	class.AllowVolumeExpansion, _ = flags.GetBool("allow-expansion")
+	class.IsDefault, _ = flags.GetBool("default")
+	sc := client.NewStorageClass(class)
+
+	if !apply || dryRun {
+		data, err := yaml.Marshal(sc)
+		if err != nil {
+			return fmt.Errorf("failed to render manifest: %w", err)
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+	if _, err := c.CreateStorageClass(ctx, sc); err != nil {
+		return fmt.Errorf("failed to create storage class: %w", err)
+	}
+
+	fmt.Printf("✓ StorageClass %s created\n", sc.Name)
+	return nil
+}
+
+func printStorageClassesTable(reports []storageClassReport) error {
+	headers := []string{
+		"NAME", "DEFAULT", "REPLICAS", "DATA LOCALITY", "RECLAIM", "BINDING", "EXPANSION", "ISSUES",
+	}
+	table := formatter.NewTableFormatter(headers)
+
+	for _, report := range reports {
+		isDefault := ""
+		if report.IsDefault {
+			isDefault = "yes"
+		}
+		table.AddRow([]string{
+			report.Name,
+			isDefault,
+			valueOrDash(report.Parameters["numberOfReplicas"]),
+			valueOrDash(report.Parameters["dataLocality"]),
+			report.ReclaimPolicy,
+			report.VolumeBindingMode,
+			fmt.Sprintf("%t", report.AllowVolumeExpansion),
+			fmt.Sprintf("%d", len(report.Problems)),
+		})
+	}
+
+	return table.Format(nil)
+}
+
+func printStorageClassDetails(report storageClassReport) error {
+	fmt.Printf("Name:              %s\n", report.Name)
+	fmt.Printf("Provisioner:       %s\n", report.Provisioner)
+	fmt.Printf("Default:           %v\n", report.IsDefault)
+	fmt.Printf("Reclaim Policy:    %s\n", report.ReclaimPolicy)
+	fmt.Printf("Binding Mode:      %s\n", report.VolumeBindingMode)
+	fmt.Printf("Allow Expansion:   %v\n", report.AllowVolumeExpansion)
+	fmt.Printf("Created:           %s\n", report.Created)
+
+	if report.Provisioner != client.LonghornCSIDriver {
+		fmt.Printf("\nNote: not provisioned by %s, Longhorn does not use these parameters\n",
+			client.LonghornCSIDriver)
+	}
+
+	fmt.Printf("\nParameters (%d):\n", len(report.Parameters))
+	if len(report.Parameters) > 0 {
+		table := formatter.NewTableFormatter([]string{"PARAMETER", "VALUE", "MEANING", "CHECK"})
+		for _, key := range sortedKeys(report.Parameters, nil) {
+			check := formatter.FormatStatus("ok", true)
+			if problem, ok := report.Problems[key]; ok {
+				check = formatter.FormatStatus("error", true) + " " + problem
+			}
+			table.AddRow([]string{
+				key,
+				report.Parameters[key],
+				describeStorageClassParameter(key, report.Parameters[key]),
+				check,
+			})
+		}
+		if err := table.Format(nil); err != nil {
+			return err
+		}
+	}
+
+	if len(report.Problems) > 0 {
+		fmt.Printf("\n%d problem(s) found; Longhorn ignores unknown parameters and invalid values\n",
+			len(report.Problems))
+	}
+
+	return nil
+}
+
+// describeStorageClassParameter explains the value of a Longhorn parameter
+func describeStorageClassParameter(key, value string) string {
+	switch key {
+	case "numberOfReplicas":
+		return value + " replica(s) per volume"
+	case "staleReplicaTimeout":
+		return "failed replicas are removed after " + value + " minute(s)"
+	case "diskSelector":
+		return "only disks tagged " + strings.Join(strings.Split(value, ","), " and ")
+	case "nodeSelector":
+		return "only nodes tagged " + strings.Join(strings.Split(value, ","), " and ")
+	case "dataLocality":
+		return "data locality " + value
+	case "recurringJobSelector":
+		jobs, err := validation.ParseRecurringJobSelector(value)
+		if err != nil {
+			return ""
+		}
+		names := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			if job.IsGroup {
+				names = append(names, "group "+job.Name)
+			} else {
+				names = append(names, "job "+job.Name)
+			}
+		}
+		return "recurring " + strings.Join(names, ", ")
+	case "fromBackup":
+		u, err := url.Parse(value)
+		if err != nil {
+			return ""
+		}
+		query := u.Query()
+		return fmt.Sprintf("restore backup %s of volume %s", query.Get("backup"), query.Get("volume"))
+	case "migratable", "encrypted":
+		if value == "true" {
+			return key
+		}
+		return "not " + key
+	default:
+		return ""
+	}
+}
+
+// sortedKeys returns the keys of params that are also in filter, or all keys
+// if filter is nil, in order
+func sortedKeys(params map[string]string, filter map[string]error) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if filter != nil && filter[key] == nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
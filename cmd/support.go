@@ -0,0 +1,101 @@
+// cmd/support.go
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/bundle"
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+var supportCmd = &cobra.Command{
+	Use:   "support",
+	Short: "Collect data for troubleshooting",
+	Long:  `Collect data for troubleshooting Longhorn.`,
+}
+
+var supportBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Manage support bundles",
+	Long:  `Manage support bundles.`,
+}
+
+var supportBundleGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a support bundle",
+	Long: `Generate a support bundle as a tar.gz archive, read purely through the
+Kubernetes API without Longhorn's support bundle manager.
+
+The bundle contains:
+  metadata.yaml           when and how the bundle was generated
+  summary.txt             resource counts and the results of 'lhcli health check'
+  longhorn/<kind>.yaml    volumes, engines, replicas, nodes, settings, backups,
+                          backup targets, recurring jobs, instance managers,
+                          engine images, orphans and share managers
+  events.yaml             Kubernetes events in the Longhorn namespace
+  pods.yaml               status of the pods in the Longhorn namespace
+
+Resources that cannot be read are listed in metadata.yaml instead of failing
+the bundle. With --redact, credentials (secret values and URL credentials)
+and IP addresses are replaced; every IP address gets a stable placeholder so
+that objects can still be related to each other.`,
+	RunE: runSupportBundleGenerate,
+}
+
+func init() {
+	rootCmd.AddCommand(supportCmd)
+	supportCmd.AddCommand(supportBundleCmd)
+	supportBundleCmd.AddCommand(supportBundleGenerateCmd)
+
+	// Support bundle generate flags
+	supportBundleGenerateCmd.Flags().
+		String("output", "", "Path of the bundle (default lhcli-support-bundle-<time>.tar.gz)")
+	supportBundleGenerateCmd.Flags().Bool("redact", false, "Redact secrets and IP addresses")
+}
+
+func runSupportBundleGenerate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	path, _ := cmd.Flags().GetString("output")
+	redact, _ := cmd.Flags().GetBool("redact")
+
+	if path == "" {
+		path = fmt.Sprintf("lhcli-support-bundle-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+
+	if !quiet {
+		fmt.Fprintln(os.Stderr, "Collecting Longhorn resources...")
+	}
+	meta, err := bundle.Generate(ctx, f, c, bundle.Options{Version: version, Redact: redact})
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write bundle file: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(path)
+		if errors.Is(err, client.ErrKubernetesRequired) {
+			return fmt.Errorf("support bundles require a Kubernetes connection (kubeconfig)")
+		}
+		return fmt.Errorf("failed to generate support bundle: %w", err)
+	}
+
+	for _, collectErr := range meta.Errors {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", collectErr)
+	}
+	fmt.Printf("✓ Support bundle written to %s\n", path)
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/pascal71/lhcli/pkg/capacity"
 	"github.com/pascal71/lhcli/pkg/client"
 	"github.com/pascal71/lhcli/pkg/formatter"
+	"github.com/pascal71/lhcli/pkg/health"
 	"github.com/pascal71/lhcli/pkg/utils"
 )
 
@@ -21,7 +23,10 @@ var (
 var volumeCmd = &cobra.Command{
 	Use:   "volume",
 	Short: "Manage Longhorn volumes",
-	Long:  `Manage Longhorn volumes including create, delete, list, and update operations.`,
+	Long: `Manage Longhorn volumes including create, delete, list, and update operations.
+
+Commands that take an existing volume also accept the PVC bound to it, either
+as pvc/<namespace>/<name> or with --pvc <namespace>/<name>.`,
 }
 
 var volumeListCmd = &cobra.Command{
@@ -34,32 +39,34 @@ var volumeListCmd = &cobra.Command{
 var volumeCreateCmd = &cobra.Command{
 	Use:   "create [name]",
 	Short: "Create a new volume",
-	Long:  `Create a new Longhorn volume with the specified configuration.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runVolumeCreate,
+	Long: `Create a new Longhorn volume with the specified configuration.
+
+With --simulate, the name may be omitted.`,
+	Args: volumeCreateArgs,
+	RunE: runVolumeCreate,
 }
 
 var volumeDeleteCmd = &cobra.Command{
-	Use:   "delete [name]",
+	Use:   "delete [name | pvc/<namespace>/<name>]",
 	Short: "Delete a volume",
 	Long:  `Delete a Longhorn volume.`,
-	Args:  cobra.ExactArgs(1),
+	Args:  volumeArgs,
 	RunE:  runVolumeDelete,
 }
 
 var volumeGetCmd = &cobra.Command{
-	Use:   "get [name]",
+	Use:   "get [name | pvc/<namespace>/<name>]",
 	Short: "Get volume details",
 	Long:  `Get detailed information about a specific volume.`,
-	Args:  cobra.ExactArgs(1),
+	Args:  volumeArgs,
 	RunE:  runVolumeGet,
 }
 
 var volumeUpdateCmd = &cobra.Command{
-	Use:   "update [name]",
+	Use:   "update [name | pvc/<namespace>/<name>]",
 	Short: "Update volume configuration",
 	Long:  `Update a Longhorn volume's configuration such as replica count, access mode, etc.`,
-	Args:  cobra.ExactArgs(1),
+	Args:  volumeArgs,
 	RunE:  runVolumeUpdate,
 }
 
@@ -76,6 +83,8 @@ func init() {
 		BoolVarP(&showReplicas, "show-replicas", "r", false, "Show replica locations (nodes and disk paths)")
 	volumeListCmd.Flags().
 		BoolVar(&showFullIDs, "full-ids", false, "Show full disk IDs and replica names without abbreviation")
+	volumeListCmd.Flags().
+		String("pvc-namespace", "", "Only show volumes bound to PVCs in this Kubernetes namespace")
 
 	// Volume create flags
 	volumeCreateCmd.Flags().String("size", "10Gi", "Volume size")
@@ -85,12 +94,16 @@ func init() {
 	volumeCreateCmd.Flags().StringSlice("node-selector", []string{}, "Node selector tags")
 	volumeCreateCmd.Flags().StringSlice("disk-selector", []string{}, "Disk selector tags")
 	volumeCreateCmd.Flags().StringToString("labels", nil, "Labels for the volume")
+	volumeCreateCmd.Flags().
+		Bool("simulate", false, "Show where replicas would be scheduled without creating the volume")
 
 	// Volume delete flags
 	volumeDeleteCmd.Flags().Bool("force", false, "Force delete")
+	addPVCFlag(volumeDeleteCmd)
 
 	// Volume get flags
 	volumeGetCmd.Flags().Bool("detailed", false, "Show detailed information")
+	addPVCFlag(volumeGetCmd)
 	volumeGetCmd.Flags().
 		BoolVar(&showFullIDs, "full-ids", false, "Show full disk IDs and replica names without abbreviation")
 
@@ -99,23 +112,38 @@ func init() {
 	volumeUpdateCmd.Flags().String("access-mode", "", "Access mode (rwo|rwx)")
 	volumeUpdateCmd.Flags().
 		String("data-locality", "", "Data locality (disabled|best-effort|strict-local)")
+	addPVCFlag(volumeUpdateCmd)
 }
 
 func runVolumeList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	pvcNamespace, _ := cmd.Flags().GetString("pvc-namespace")
+
 	c, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	volumes, err := c.Volumes().List()
+	volumes, err := c.Volumes().List(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list volumes: %w", err)
 	}
 
+	if pvcNamespace != "" {
+		filtered := make([]client.Volume, 0, len(volumes))
+		for _, volume := range volumes {
+			if volume.KubernetesStatus.Namespace == pvcNamespace {
+				filtered = append(filtered, volume)
+			}
+		}
+		volumes = filtered
+	}
+
 	// If show-replicas flag is set, fetch node information
 	if showReplicas && (output == "table" || output == "wide" || output == "") {
 		// Fetch all nodes to get disk path information
-		nodes, err := c.Nodes().List()
+		nodes, err := c.Nodes().List(ctx)
 		if err != nil {
 			// Don't fail completely, just warn
 			fmt.Fprintf(
@@ -168,7 +196,7 @@ func runVolumeList(cmd *cobra.Command, args []string) error {
 }
 
 func runVolumeCreate(cmd *cobra.Command, args []string) error {
-	volumeName := args[0]
+	ctx := cmd.Context()
 
 	size, _ := cmd.Flags().GetString("size")
 	replicas, _ := cmd.Flags().GetInt("replicas")
@@ -177,14 +205,19 @@ func runVolumeCreate(cmd *cobra.Command, args []string) error {
 	nodeSelector, _ := cmd.Flags().GetStringSlice("node-selector")
 	diskSelector, _ := cmd.Flags().GetStringSlice("disk-selector")
 	labels, _ := cmd.Flags().GetStringToString("labels")
+	simulate, _ := cmd.Flags().GetBool("simulate")
 
 	c, err := getClient()
 	if err != nil {
 		return err
 	}
 
+	if simulate {
+		return runVolumeSimulate(cmd, c, size, replicas, nodeSelector, diskSelector)
+	}
+
 	input := &client.VolumeCreateInput{
-		Name:             volumeName,
+		Name:             args[0],
 		Size:             size,
 		NumberOfReplicas: replicas,
 		Frontend:         frontend,
@@ -194,7 +227,7 @@ func runVolumeCreate(cmd *cobra.Command, args []string) error {
 		Labels:           labels,
 	}
 
-	volume, err := c.Volumes().Create(input)
+	volume, err := c.Volumes().Create(ctx, input)
 	if err != nil {
 		return fmt.Errorf("failed to create volume: %w", err)
 	}
@@ -203,8 +236,59 @@ func runVolumeCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runVolumeSimulate reports where the replicas of a prospective volume would be
+// placed, using the node disk data and the scheduler settings of the cluster
+func runVolumeSimulate(
+	cmd *cobra.Command,
+	c *client.Client,
+	size string,
+	replicas int,
+	nodeSelector, diskSelector []string,
+) error {
+	ctx := cmd.Context()
+
+	sizeBytes, err := utils.ParseSize(size)
+	if err != nil {
+		return fmt.Errorf("invalid size: %w", err)
+	}
+
+	nodes, err := c.Nodes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	result := capacity.Simulate(nodes, getSchedulingSettings(ctx, c), capacity.SimulationInput{
+		Size:         sizeBytes,
+		Replicas:     replicas,
+		NodeSelector: nodeSelector,
+		DiskSelector: diskSelector,
+	})
+
+	switch output {
+	case "json":
+		err = formatter.NewJSONFormatter(true).Format(result)
+	case "yaml":
+		err = formatter.NewYAMLFormatter().Format(result)
+	default:
+		err = printSimulationResult(result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !result.Scheduled {
+		return silentExit(cmd, 2, "volume cannot be fully scheduled")
+	}
+	return nil
+}
+
 func runVolumeUpdate(cmd *cobra.Command, args []string) error {
-	volumeName := args[0]
+	ctx := cmd.Context()
+
+	ref, err := volumeArgRef(cmd, args)
+	if err != nil {
+		return err
+	}
 
 	replicas, _ := cmd.Flags().GetInt("replicas")
 	accessMode, _ := cmd.Flags().GetString("access-mode")
@@ -220,6 +304,11 @@ func runVolumeUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	volumeName, err := resolveVolumeName(ctx, c, ref)
+	if err != nil {
+		return err
+	}
+
 	// Build update input
 	update := &client.VolumeUpdateInput{}
 
@@ -234,7 +323,7 @@ func runVolumeUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Perform the update
-	volume, err := c.Volumes().Update(volumeName, update)
+	volume, err := c.Volumes().Update(ctx, volumeName, update)
 	if err != nil {
 		return fmt.Errorf("failed to update volume: %w", err)
 	}
@@ -262,21 +351,31 @@ func runVolumeUpdate(cmd *cobra.Command, args []string) error {
 }
 
 func runVolumeDelete(cmd *cobra.Command, args []string) error {
-	volumeName := args[0]
-	force, _ := cmd.Flags().GetBool("force")
+	ctx := cmd.Context()
 
-	if !force &&
-		!utils.Confirm(fmt.Sprintf("Are you sure you want to delete volume %s?", volumeName)) {
-		fmt.Println("Deletion cancelled")
-		return nil
+	ref, err := volumeArgRef(cmd, args)
+	if err != nil {
+		return err
 	}
+	force, _ := cmd.Flags().GetBool("force")
 
 	c, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	if err := c.Volumes().Delete(volumeName); err != nil {
+	volumeName, err := resolveVolumeName(ctx, c, ref)
+	if err != nil {
+		return err
+	}
+
+	if !force &&
+		!utils.Confirm(fmt.Sprintf("Are you sure you want to delete volume %s?", volumeName)) {
+		fmt.Println("Deletion cancelled")
+		return nil
+	}
+
+	if err := c.Volumes().Delete(ctx, volumeName); err != nil {
 		return fmt.Errorf("failed to delete volume: %w", err)
 	}
 
@@ -285,7 +384,12 @@ func runVolumeDelete(cmd *cobra.Command, args []string) error {
 }
 
 func runVolumeGet(cmd *cobra.Command, args []string) error {
-	volumeName := args[0]
+	ctx := cmd.Context()
+
+	ref, err := volumeArgRef(cmd, args)
+	if err != nil {
+		return err
+	}
 	detailed, _ := cmd.Flags().GetBool("detailed")
 
 	c, err := getClient()
@@ -293,7 +397,12 @@ func runVolumeGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	volume, err := c.Volumes().Get(volumeName)
+	volumeName, err := resolveVolumeName(ctx, c, ref)
+	if err != nil {
+		return err
+	}
+
+	volume, err := c.Volumes().Get(ctx, volumeName)
 	if err != nil {
 		return fmt.Errorf("failed to get volume: %w", err)
 	}
@@ -301,11 +410,15 @@ func runVolumeGet(cmd *cobra.Command, args []string) error {
 	// Handle output format
 	switch output {
 	case "json":
-		return formatter.NewJSONFormatter(true).Format(volume)
+		return formatter.NewJSONFormatter(true).Format(volumeWithShareManager(ctx, c, volume))
 	case "yaml":
-		return formatter.NewYAMLFormatter().Format(volume)
+		return formatter.NewYAMLFormatter().Format(volumeWithShareManager(ctx, c, volume))
 	default:
-		return printVolumeDetails(volume, detailed)
+		if err := printVolumeDetails(volume, detailed); err != nil {
+			return err
+		}
+		printVolumeShareManager(ctx, c, volume)
+		return nil
 	}
 }
 
@@ -350,6 +463,9 @@ func printVolumesWide(volumes []client.Volume) error {
 		"ROBUSTNESS",
 		"FRONTEND",
 		"ACCESS",
+		"PVC",
+		"NAMESPACE",
+		"WORKLOAD",
 		"CREATED",
 	}
 	formatter := formatter.NewTableFormatter(headers)
@@ -375,6 +491,9 @@ func printVolumesWide(volumes []client.Volume) error {
 			robustness,
 			volume.Frontend,
 			volume.AccessMode,
+			valueOrDash(volume.KubernetesStatus.PVCName),
+			valueOrDash(volume.KubernetesStatus.Namespace),
+			valueOrDash(volumeWorkloads(volume)),
 			volume.Created,
 		})
 	}
@@ -382,6 +501,28 @@ func printVolumesWide(volumes []client.Volume) error {
 	return formatter.Format(nil)
 }
 
+// volumeWorkloads returns the workloads using a volume as kind/name, falling
+// back to the pod name for pods without an owning workload
+func volumeWorkloads(volume client.Volume) string {
+	var workloads []string
+	seen := make(map[string]bool)
+	for _, ws := range volume.KubernetesStatus.WorkloadsStatus {
+		workload := ws.PodName
+		if ws.WorkloadName != "" {
+			workload = ws.WorkloadName
+			if ws.WorkloadType != "" {
+				workload = ws.WorkloadType + "/" + ws.WorkloadName
+			}
+		}
+		if workload == "" || seen[workload] {
+			continue
+		}
+		seen[workload] = true
+		workloads = append(workloads, workload)
+	}
+	return strings.Join(workloads, ",")
+}
+
 // Print volumes with detailed replica information
 func printVolumesWithReplicas(volumes []client.Volume) error {
 	for i, volume := range volumes {
@@ -542,6 +683,26 @@ func printVolumeDetails(volume *client.Volume, detailed bool) error {
 		fmt.Printf("Labels:            <none>\n")
 	}
 
+	if k8s := volume.KubernetesStatus; k8s.PVName != "" || k8s.PVCName != "" {
+		fmt.Println("\nKubernetes:")
+		fmt.Printf("  PV:        %s (%s)\n", valueOrDash(k8s.PVName), valueOrDash(k8s.PVStatus))
+		if k8s.PVCName != "" {
+			fmt.Printf("  PVC:       %s/%s\n", k8s.Namespace, k8s.PVCName)
+		} else if k8s.LastPVCRefAt != "" {
+			fmt.Printf("  PVC:       <none> (last bound at %s)\n", k8s.LastPVCRefAt)
+		}
+		for _, ws := range k8s.WorkloadsStatus {
+			workload := ""
+			if ws.WorkloadName != "" {
+				workload = fmt.Sprintf(" of %s/%s", ws.WorkloadType, ws.WorkloadName)
+			}
+			fmt.Printf("  Pod:       %s (%s)%s\n", ws.PodName, ws.PodStatus, workload)
+		}
+		if len(k8s.WorkloadsStatus) == 0 && k8s.LastPodRefAt != "" {
+			fmt.Printf("  Pod:       <none> (last used at %s)\n", k8s.LastPodRefAt)
+		}
+	}
+
 	if detailed || len(volume.Conditions) > 0 {
 		fmt.Println("\nConditions:")
 		for name, condition := range volume.Conditions {
@@ -622,20 +783,71 @@ func printVolumeDetails(volume *client.Volume, detailed bool) error {
 	return nil
 }
 
-func getVolumeState(volume client.Volume) string {
-	// Check state field first
-	if volume.State != "" {
-		return volume.State
+func printSimulationResult(result *capacity.SimulationResult) error {
+	fmt.Printf("Simulating %d replica(s) of %s\n\n", result.Input.Replicas,
+		utils.FormatSize(result.Input.Size))
+
+	fmt.Println("Candidate disks:")
+	headers := []string{"NODE", "ZONE", "DISK", "PATH", "ELIGIBLE", "REASON"}
+	candidates := formatter.NewTableFormatter(headers)
+	for _, c := range result.Candidates {
+		eligible := "yes"
+		if !c.Eligible {
+			eligible = "no"
+		}
+		reason := c.Reason
+		if reason == "" {
+			reason = "-"
+		}
+		candidates.AddRow([]string{
+			c.Node,
+			c.Zone,
+			shortenDiskID(c.DiskID),
+			c.Path,
+			eligible,
+			reason,
+		})
+	}
+	if err := candidates.Format(nil); err != nil {
+		return err
 	}
 
-	// Check conditions
-	if scheduledCondition, exists := volume.Conditions["Scheduled"]; exists {
-		if scheduledCondition.Status == "True" {
-			return "Attached"
+	fmt.Println("\nPlacement:")
+	if len(result.Placements) == 0 {
+		fmt.Println("  <none>")
+	} else {
+		headers := []string{"REPLICA", "NODE", "ZONE", "DISK", "PATH"}
+		placements := formatter.NewTableFormatter(headers)
+		for _, p := range result.Placements {
+			placements.AddRow([]string{
+				fmt.Sprintf("%d", p.Replica),
+				p.Node,
+				p.Zone,
+				shortenDiskID(p.DiskID),
+				p.Path,
+			})
+		}
+		if err := placements.Format(nil); err != nil {
+			return err
 		}
 	}
 
-	return "Detached"
+	fmt.Println()
+	if result.Scheduled {
+		fmt.Printf("✓ All %d replica(s) can be scheduled\n", result.Input.Replicas)
+		return nil
+	}
+
+	fmt.Printf("✗ Only %d of %d replica(s) can be scheduled\n",
+		len(result.Placements), result.Input.Replicas)
+	for _, failure := range result.Failures {
+		fmt.Printf("  %s\n", failure)
+	}
+	return nil
+}
+
+func getVolumeState(volume client.Volume) string {
+	return health.VolumeState(volume)
 }
 
 // Helper function to shorten replica names for better display
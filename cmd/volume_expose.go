@@ -0,0 +1,106 @@
+// cmd/volume_expose.go
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+var volumeExposeCmd = &cobra.Command{
+	Use:   "expose [volume-name]",
+	Short: "Create a PV and PVC for an existing volume",
+	Long: `Create a PersistentVolume backed by the Longhorn CSI driver for an existing
+volume, and a PersistentVolumeClaim bound to it, so workloads can use the
+volume again, e.g. after restoring it from a backup.
+
+The PV is named after the volume unless --pv-name is given and is reserved
+for the new PVC. Its access mode follows the volume (rwo or rwx) and its
+reclaim policy is Retain, so deleting the PVC never deletes the volume.
+With --dry-run the manifests are printed instead of created.
+
+The namespace of the PVC must be given with --pvc-namespace. The global
+--namespace flag names the namespace Longhorn runs in (longhorn-system by
+default), where workloads rarely live, so it is not used for the PVC.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVolumeExpose,
+}
+
+func init() {
+	volumeCmd.AddCommand(volumeExposeCmd)
+
+	// Volume expose flags
+	volumeExposeCmd.Flags().String("pvc-name", "", "Name of the PVC to create (required)")
+	volumeExposeCmd.Flags().
+		String("pvc-namespace", "", "Kubernetes namespace of the PVC to create (required)")
+	volumeExposeCmd.Flags().String("pv-name", "", "Name of the PV to create (default volume name)")
+	volumeExposeCmd.Flags().
+		String("storage-class", client.DefaultStaticStorageClass, "Storage class of the PV and PVC")
+	volumeExposeCmd.Flags().String("fs-type", client.DefaultFSType, "Filesystem of the volume")
+	_ = volumeExposeCmd.MarkFlagRequired("pvc-name")
+	_ = volumeExposeCmd.MarkFlagRequired("pvc-namespace")
+}
+
+func runVolumeExpose(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	volumeName := args[0]
+	input := &client.VolumeExposeInput{}
+	input.PVCName, _ = cmd.Flags().GetString("pvc-name")
+	input.PVCNamespace, _ = cmd.Flags().GetString("pvc-namespace")
+	input.PVName, _ = cmd.Flags().GetString("pv-name")
+	input.StorageClass, _ = cmd.Flags().GetString("storage-class")
+	input.FSType, _ = cmd.Flags().GetString("fs-type")
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	volume, err := c.Volumes().Get(ctx, volumeName)
+	if err != nil {
+		return fmt.Errorf("failed to get volume: %w", err)
+	}
+
+	if dryRun {
+		return printVolumeExposeManifests(volume, input)
+	}
+
+	pv, pvc, err := c.ExposeVolume(ctx, volume, input)
+	if err != nil {
+		return fmt.Errorf("failed to expose volume: %w", err)
+	}
+
+	fmt.Printf("✓ PersistentVolume %s created for volume %s\n", pv.Name, volume.Name)
+	fmt.Printf("✓ PersistentVolumeClaim %s/%s created and bound to it\n", pvc.Namespace, pvc.Name)
+
+	return nil
+}
+
+// printVolumeExposeManifests prints the PV and PVC that expose would create
+func printVolumeExposeManifests(volume *client.Volume, input *client.VolumeExposeInput) error {
+	pv, err := client.NewVolumePV(volume, input)
+	if err != nil {
+		return err
+	}
+	pvc, err := client.NewVolumePVC(volume, input)
+	if err != nil {
+		return err
+	}
+
+	for i, object := range []interface{}{pv, pvc} {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return fmt.Errorf("failed to render manifest: %w", err)
+		}
+		if i > 0 {
+			fmt.Println("---")
+		}
+		fmt.Print(string(data))
+	}
+
+	return nil
+}
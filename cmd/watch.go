@@ -0,0 +1,165 @@
+// cmd/watch.go
+package cmd
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pascal71/lhcli/pkg/alert"
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/metrics"
+	"github.com/pascal71/lhcli/pkg/monitor"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch Longhorn resources and react to changes",
+	Long:  `Watch Longhorn resources and react to changes, such as firing alerts.`,
+}
+
+var watchAlertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Evaluate alert rules against the cluster",
+	Long: `Evaluate alert rules against the live cluster state and send a notification
+when an alert fires or resolves.
+
+Rules are read from a YAML file:
+
+  webhook: https://alerts.example.com/longhorn   # optional, default is stdout
+  interval: 30s
+  rules:
+    - name: volume-degraded
+      condition: volume-degraded
+      for: 10m
+    - name: backup-stale
+      condition: backup-age
+      maxAge: 26h
+      severity: critical
+    - name: disk-full
+      condition: disk-usage
+      threshold: 85
+
+Conditions: volume-degraded, volume-faulted, replicas-below-spec, backup-age
+(requires maxAge and a Kubernetes connection, also fires for volumes that were
+never backed up), disk-usage (requires threshold in percent) and
+node-not-ready. A rule fires once its condition has held for the "for"
+duration. Notifications are JSON objects, posted to the webhook or written to
+stdout one per line.`,
+	RunE: runWatchAlerts,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.AddCommand(watchAlertsCmd)
+
+	// Watch alerts flags
+	watchAlertsCmd.Flags().StringP("file", "f", "", "Alert rules file")
+	watchAlertsCmd.Flags().String("webhook", "", "Webhook URL (overrides the rules file)")
+	watchAlertsCmd.Flags().
+		Duration("interval", 0, "Evaluation interval (overrides the rules file, default 30s)")
+	watchAlertsCmd.MarkFlagRequired("file")
+}
+
+func runWatchAlerts(cmd *cobra.Command, args []string) error {
+	file, _ := cmd.Flags().GetString("file")
+	webhook, _ := cmd.Flags().GetString("webhook")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	config, err := alert.LoadConfig(file)
+	if err != nil {
+		return err
+	}
+	if webhook == "" {
+		webhook = config.Webhook
+	}
+	if interval == 0 {
+		interval = time.Duration(config.Interval)
+	}
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+
+	var notifier alert.Notifier = alert.NewWriterNotifier(os.Stdout)
+	if webhook != "" {
+		notifier = alert.NewWebhookNotifier(webhook)
+	}
+
+	c, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	snapshot, err := alertSnapshotSource(ctx, c, config.Rules)
+	if err != nil {
+		return ignoreCanceled(err)
+	}
+
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Evaluating %d rule(s) every %s\n", len(config.Rules), interval)
+	}
+
+	evaluator := alert.NewEvaluator(config.Rules, notifier)
+	err = monitor.Poll(ctx, interval, func(ctx gocontext.Context) error {
+		s, err := snapshot()
+		if err != nil {
+			return err
+		}
+		return evaluator.Evaluate(s)
+	})
+	return ignoreCanceled(err)
+}
+
+// alertSnapshotSource returns a function that captures the cluster state.
+// With a Kubernetes connection the state is read from informer caches,
+// otherwise it is listed through the API on every call. The API lists no
+// backups, so backup-age rules require a Kubernetes connection.
+func alertSnapshotSource(
+	ctx gocontext.Context,
+	c *client.Client,
+	rules []alert.Rule,
+) (func() (metrics.Snapshot, error), error) {
+	resources, err := c.NewResourceCache()
+	if err == nil {
+		if err := resources.Start(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load resources: %w", err)
+		}
+		return func() (metrics.Snapshot, error) {
+			return metrics.Snapshot{
+				Volumes: resources.Volumes(),
+				Nodes:   resources.Nodes(),
+				Backups: resources.Backups(),
+				Time:    time.Now(),
+			}, nil
+		}, nil
+	}
+	if !errors.Is(err, client.ErrWatchNotSupported) {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.Condition == alert.ConditionBackupAge {
+			return nil, fmt.Errorf(
+				"rule %q: %s requires a Kubernetes connection to read backups",
+				rule.Name, rule.Condition,
+			)
+		}
+	}
+
+	return func() (metrics.Snapshot, error) {
+		volumes, err := c.Volumes().List(ctx)
+		if err != nil {
+			return metrics.Snapshot{}, fmt.Errorf("failed to list volumes: %w", err)
+		}
+		nodes, err := c.Nodes().List(ctx)
+		if err != nil {
+			return metrics.Snapshot{}, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		return metrics.Snapshot{Volumes: volumes, Nodes: nodes, Time: time.Now()}, nil
+	}, nil
+}
@@ -0,0 +1,233 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// csiParameterPrefix marks parameters handled by the CSI sidecars rather
+// than Longhorn, e.g. csi.storage.k8s.io/fstype
+const csiParameterPrefix = "csi.storage.k8s.io/"
+
+// storageClassParameters are the parameters Longhorn reads from a
+// StorageClass, with the validation of their values. Longhorn silently
+// ignores any other parameter.
+var storageClassParameters = map[string]func(string) error{
+	"numberOfReplicas":                 validateReplicaCountParameter,
+	"staleReplicaTimeout":              validateNonNegativeInt,
+	"fromBackup":                       ValidateBackupURL,
+	"diskSelector":                     ValidateTagList,
+	"nodeSelector":                     ValidateTagList,
+	"recurringJobSelector":             ValidateRecurringJobSelector,
+	"dataLocality":                     ValidateDataLocality,
+	"dataEngine":                       oneOf("v1", "v2"),
+	"migratable":                       validateBool,
+	"encrypted":                        validateBool,
+	"disableRevisionCounter":           validateBool,
+	"fsType":                           oneOf("ext4", "xfs"),
+	"mkfsParams":                       anyValue,
+	"nfsOptions":                       anyValue,
+	"backupTargetName":                 anyValue,
+	"backingImage":                     anyValue,
+	"backingImageChecksum":             anyValue,
+	"backingImageDataSourceType":       backingImageSourceType,
+	"backingImageDataSourceParameters": validateJSONObject,
+	"replicaAutoBalance":               replicaAutoBalanceMode,
+	"unmapMarkSnapChainRemoved":        overridableSetting,
+	"replicaSoftAntiAffinity":          overridableSetting,
+	"replicaZoneSoftAntiAffinity":      overridableSetting,
+	"replicaDiskSoftAntiAffinity":      overridableSetting,
+	"freezeFilesystemForSnapshot":      overridableSetting,
+	"snapshotDataIntegrity":            oneOf("ignored", "disabled", "enabled", "fast-check"),
+	"snapshotMaxCount":                 validateNonNegativeInt,
+	"snapshotMaxSize":                  validateNonNegativeInt,
+}
+
+// Values of StorageClass parameters that select from a fixed set
+var (
+	// overridableSetting overrides a global setting or keeps it ("ignored")
+	overridableSetting     = oneOf("ignored", "enabled", "disabled")
+	replicaAutoBalanceMode = oneOf("ignored", "disabled", "least-effort", "best-effort")
+	backingImageSourceType = oneOf("download", "upload", "export-from-volume", "restore")
+)
+
+// RecurringJobSelector is an entry of the recurringJobSelector parameter
+type RecurringJobSelector struct {
+	Name    string `json:"name"`
+	IsGroup bool   `json:"isGroup"`
+}
+
+// ValidateStorageClassParameters validates the parameters of a Longhorn
+// StorageClass and returns the problems found, keyed by parameter. Unknown
+// parameters are reported with the closest known name, since Longhorn
+// ignores them without a warning.
+func ValidateStorageClassParameters(params map[string]string) map[string]error {
+	problems := make(map[string]error)
+	for key, value := range params {
+		if strings.HasPrefix(key, csiParameterPrefix) {
+			continue
+		}
+		validate, ok := storageClassParameters[key]
+		if !ok {
+			problems[key] = unknownParameter(key)
+			continue
+		}
+		if err := validate(value); err != nil {
+			problems[key] = err
+		}
+	}
+	return problems
+}
+
+// ValidateDataLocality validates a data locality mode
+func ValidateDataLocality(locality string) error {
+	return oneOf("disabled", "best-effort", "strict-local")(locality)
+}
+
+// ValidateTagList validates a comma-separated list of node or disk tags
+func ValidateTagList(tags string) error {
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return fmt.Errorf("empty tag in %q", tags)
+		}
+		if err := ValidateLabelValue(tag); err != nil {
+			return fmt.Errorf("invalid tag: %s", tag)
+		}
+	}
+	return nil
+}
+
+// ValidateRecurringJobSelector validates a recurringJobSelector value, a
+// JSON list of recurring jobs or groups
+func ValidateRecurringJobSelector(selector string) error {
+	_, err := ParseRecurringJobSelector(selector)
+	return err
+}
+
+// ParseRecurringJobSelector parses a recurringJobSelector value
+func ParseRecurringJobSelector(selector string) ([]RecurringJobSelector, error) {
+	var jobs []RecurringJobSelector
+	decoder := json.NewDecoder(strings.NewReader(selector))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&jobs); err != nil {
+		return nil, fmt.Errorf(
+			`invalid recurring job selector (expected [{"name":"...","isGroup":false}]): %v`, err)
+	}
+	for _, job := range jobs {
+		if job.Name == "" {
+			return nil, fmt.Errorf("recurring job selector entry without a name")
+		}
+	}
+	return jobs, nil
+}
+
+// ValidateBackupURL validates the URL of a backup to restore from
+func ValidateBackupURL(backupURL string) error {
+	u, err := url.Parse(backupURL)
+	if err != nil || u.Scheme == "" {
+		return fmt.Errorf("invalid backup URL: %s", backupURL)
+	}
+	query := u.Query()
+	if query.Get("backup") == "" || query.Get("volume") == "" {
+		return fmt.Errorf("backup URL must include the backup and volume query parameters")
+	}
+	return nil
+}
+
+func validateReplicaCountParameter(value string) error {
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("not a number: %s", value)
+	}
+	return ValidateReplicaCount(count)
+}
+
+func validateNonNegativeInt(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("not a number: %s", value)
+	}
+	if n < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
+
+func validateBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("not a boolean: %s (expected true or false)", value)
+	}
+	return nil
+}
+
+func validateJSONObject(value string) error {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return fmt.Errorf("not a JSON object: %v", err)
+	}
+	return nil
+}
+
+func anyValue(string) error {
+	return nil
+}
+
+// oneOf returns a validation accepting the given values only
+func oneOf(valid ...string) func(string) error {
+	return func(value string) error {
+		for _, v := range valid {
+			if value == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value: %s (valid values: %s)", value, strings.Join(valid, ", "))
+	}
+}
+
+// unknownParameter reports a parameter Longhorn does not know, suggesting
+// the closest known one for likely typos
+func unknownParameter(key string) error {
+	names := make([]string, 0, len(storageClassParameters))
+	for name := range storageClassParameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	best, bestDistance := "", len(key)/3+1
+	for _, name := range names {
+		distance := editDistance(strings.ToLower(key), strings.ToLower(name))
+		if distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	if best != "" {
+		return fmt.Errorf("unknown parameter, did you mean %s?", best)
+	}
+	return fmt.Errorf("unknown parameter, Longhorn ignores it")
+}
+
+// editDistance returns the Levenshtein distance of two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateStorageClassParameters(t *testing.T) {
+	params := map[string]string{
+		"numberOfReplicas":          "3",
+		"staleReplicaTimeout":       "2880",
+		"dataLocality":              "best-effort",
+		"diskSelector":              "ssd,fast",
+		"recurringJobSelector":      `[{"name":"daily","isGroup":true}]`,
+		"csi.storage.k8s.io/fstype": "xfs",
+		"fromBackup":                "s3://bucket@us-east-1/?backup=backup-1&volume=vol-1",
+	}
+	if problems := ValidateStorageClassParameters(params); len(problems) != 0 {
+		t.Fatalf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateStorageClassParametersProblems(t *testing.T) {
+	params := map[string]string{
+		"numberofReplica":      "3",
+		"dataLocality":         "best_effort",
+		"numberOfReplicas":     "three",
+		"recurringJobSelector": `[{"name":"daily","group":true}]`,
+		"diskSelector":         "ssd,,fast",
+		"fromBackup":           "s3://bucket@us-east-1/",
+		"someOtherSetting":     "x",
+	}
+	problems := ValidateStorageClassParameters(params)
+
+	for key := range params {
+		if problems[key] == nil {
+			t.Errorf("expected a problem for %s", key)
+		}
+	}
+	if msg := problems["numberofReplica"].Error(); !strings.Contains(msg, "numberOfReplicas") {
+		t.Errorf("expected a suggestion for the typo, got %q", msg)
+	}
+	if msg := problems["someOtherSetting"].Error(); strings.Contains(msg, "did you mean") {
+		t.Errorf("expected no suggestion for an unrelated name, got %q", msg)
+	}
+}
+
+func TestParseRecurringJobSelector(t *testing.T) {
+	jobs, err := ParseRecurringJobSelector(
+		`[{"name":"daily","isGroup":true},{"name":"backup-weekly","isGroup":false}]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jobs) != 2 || !jobs[0].IsGroup || jobs[1].Name != "backup-weekly" {
+		t.Errorf("unexpected jobs: %+v", jobs)
+	}
+
+	if _, err := ParseRecurringJobSelector(`[{"isGroup":true}]`); err == nil {
+		t.Error("expected an error for an entry without a name")
+	}
+}
@@ -70,6 +70,11 @@ func ValidateFrontend(frontend string) error {
     return fmt.Errorf("invalid frontend type: %s (valid types: %s)", frontend, strings.Join(validFrontends, ", "))
 }
 
+// ValidateAccessMode validates a volume access mode
+func ValidateAccessMode(mode string) error {
+    return oneOf("rwo", "rwx")(mode)
+}
+
 // ValidateLabels validates label format
 func ValidateLabels(labels map[string]string) error {
     for key, value := range labels {
@@ -9,6 +9,6 @@ import (
 func main() {
 	cmd.SetBuildInfo(Version, BuildDate)
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
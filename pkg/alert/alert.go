@@ -0,0 +1,264 @@
+// pkg/alert/alert.go
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pascal71/lhcli/pkg/metrics"
+	"github.com/pascal71/lhcli/pkg/utils"
+)
+
+// Alert statuses
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Notification is sent when an alert fires or resolves
+type Notification struct {
+	Rule      string    `json:"rule"`
+	Condition string    `json:"condition"`
+	Severity  string    `json:"severity"`
+	Status    string    `json:"status"`
+	Subject   string    `json:"subject"`
+	Message   string    `json:"message"`
+	Since     time.Time `json:"since"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier delivers notifications
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// WriterNotifier writes notifications as JSON lines
+type WriterNotifier struct {
+	writer io.Writer
+}
+
+// NewWriterNotifier creates a notifier that writes to w
+func NewWriterNotifier(w io.Writer) *WriterNotifier {
+	return &WriterNotifier{writer: w}
+}
+
+// Notify writes the notification
+func (w *WriterNotifier) Notify(n Notification) error {
+	return json.NewEncoder(w.writer).Encode(n)
+}
+
+// WebhookNotifier posts notifications as JSON to a URL
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts to url
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify posts the notification
+func (w *WebhookNotifier) Notify(n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	resp, err := w.httpClient.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// alertKey identifies an alert of a rule for a subject
+type alertKey struct {
+	rule    string
+	subject string
+}
+
+// alertState tracks a rule that currently matches a subject
+type alertState struct {
+	since   time.Time
+	message string
+	firing  bool
+}
+
+// Evaluator checks rules against snapshots of the cluster and notifies when
+// alerts fire and resolve
+type Evaluator struct {
+	rules    []Rule
+	notifier Notifier
+	active   map[alertKey]*alertState
+}
+
+// NewEvaluator creates an evaluator for the given rules
+func NewEvaluator(rules []Rule, notifier Notifier) *Evaluator {
+	return &Evaluator{
+		rules:    rules,
+		notifier: notifier,
+		active:   make(map[alertKey]*alertState),
+	}
+}
+
+// Evaluate checks all rules against a snapshot. A notification that cannot be
+// delivered is retried on the next evaluation.
+func (e *Evaluator) Evaluate(s metrics.Snapshot) error {
+	var errs []error
+
+	for _, rule := range e.rules {
+		matches := check(rule, s)
+
+		subjects := make([]string, 0, len(matches))
+		for subject := range matches {
+			subjects = append(subjects, subject)
+		}
+		sort.Strings(subjects)
+
+		for _, subject := range subjects {
+			key := alertKey{rule: rule.Name, subject: subject}
+			state, ok := e.active[key]
+			if !ok {
+				state = &alertState{since: s.Time}
+				e.active[key] = state
+			}
+			state.message = matches[subject]
+
+			if state.firing || s.Time.Sub(state.since) < time.Duration(rule.For) {
+				continue
+			}
+			err := e.notify(rule, subject, state, StatusFiring, s.Time)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			state.firing = true
+		}
+
+		for key, state := range e.active {
+			if key.rule != rule.Name || matches[key.subject] != "" {
+				continue
+			}
+			if state.firing {
+				err := e.notify(rule, key.subject, state, StatusResolved, s.Time)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+			}
+			delete(e.active, key)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (e *Evaluator) notify(
+	rule Rule,
+	subject string,
+	state *alertState,
+	status string,
+	now time.Time,
+) error {
+	return e.notifier.Notify(Notification{
+		Rule:      rule.Name,
+		Condition: rule.Condition,
+		Severity:  rule.Severity,
+		Status:    status,
+		Subject:   subject,
+		Message:   state.message,
+		Since:     state.since,
+		Timestamp: now,
+	})
+}
+
+// check returns the subjects that currently match a rule, with a message
+func check(rule Rule, s metrics.Snapshot) map[string]string {
+	matches := make(map[string]string)
+
+	switch rule.Condition {
+	case ConditionVolumeDegraded, ConditionVolumeFaulted:
+		want := strings.TrimPrefix(rule.Condition, "volume-")
+		for _, volume := range s.Volumes {
+			if strings.EqualFold(volume.Robustness, want) {
+				matches["volume/"+volume.Name] = fmt.Sprintf("volume %s is %s", volume.Name, want)
+			}
+		}
+
+	case ConditionReplicasBelowSpec:
+		for _, volume := range s.Volumes {
+			// Detached volumes have no running replicas
+			if !strings.EqualFold(volume.State, "attached") {
+				continue
+			}
+			if healthy := metrics.HealthyReplicas(volume); healthy < volume.NumberOfReplicas {
+				matches["volume/"+volume.Name] = fmt.Sprintf(
+					"volume %s has %d of %d healthy replicas",
+					volume.Name, healthy, volume.NumberOfReplicas,
+				)
+			}
+		}
+
+	case ConditionBackupAge:
+		last := metrics.LastBackupTimes(s)
+		for _, volume := range s.Volumes {
+			t, ok := last[volume.Name]
+			if !ok {
+				matches["volume/"+volume.Name] = fmt.Sprintf(
+					"volume %s has never been backed up", volume.Name,
+				)
+				continue
+			}
+			if age := s.Time.Sub(t); age > time.Duration(rule.MaxAge) {
+				matches["volume/"+volume.Name] = fmt.Sprintf(
+					"last backup of volume %s is %s old",
+					volume.Name, age.Round(time.Minute),
+				)
+			}
+		}
+
+	case ConditionDiskUsage:
+		for _, node := range s.Nodes {
+			for name, disk := range node.Disks {
+				if disk.StorageMaximum <= 0 {
+					continue
+				}
+				used := disk.StorageMaximum - disk.StorageAvailable
+				percent := float64(used) / float64(disk.StorageMaximum) * 100
+				if percent > rule.Threshold {
+					matches["disk/"+node.Name+"/"+name] = fmt.Sprintf(
+						"disk %s on node %s is %.1f%% full (%s of %s)",
+						name, node.Name, percent,
+						utils.FormatSize(used), utils.FormatSize(disk.StorageMaximum),
+					)
+				}
+			}
+		}
+
+	case ConditionNodeNotReady:
+		for _, node := range s.Nodes {
+			if cond, ok := node.Conditions["Ready"]; !ok || cond.Status != "True" {
+				matches["node/"+node.Name] = fmt.Sprintf("node %s is not ready", node.Name)
+			}
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,195 @@
+// pkg/alert/alert_test.go
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/metrics"
+)
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig([]byte(`
+webhook: http://localhost:9000/hook
+interval: 30s
+rules:
+  - name: degraded
+    condition: volume-degraded
+    for: 10m
+  - name: stale-backup
+    condition: backup-age
+    maxAge: 26h
+    severity: critical
+  - name: disk-full
+    condition: disk-usage
+    threshold: 85
+`))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if time.Duration(config.Interval) != 30*time.Second {
+		t.Errorf("interval = %v, want 30s", time.Duration(config.Interval))
+	}
+	if time.Duration(config.Rules[0].For) != 10*time.Minute {
+		t.Errorf("for = %v, want 10m", time.Duration(config.Rules[0].For))
+	}
+	if config.Rules[0].Severity != "warning" {
+		t.Errorf("default severity = %q, want warning", config.Rules[0].Severity)
+	}
+
+	invalid := []string{
+		`rules: [{name: a, condition: unknown}]`,
+		`rules: [{name: a, condition: backup-age}]`,
+		`rules: [{name: a, condition: disk-usage, threshold: 150}]`,
+		`rules: [{name: a, condition: node-not-ready}, {name: a, condition: volume-faulted}]`,
+		`rules: [{name: a, condition: volume-degraded, for: soon}]`,
+		`rules: []`,
+	}
+	for _, data := range invalid {
+		if _, err := ParseConfig([]byte(data)); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
+
+func TestEvaluatorWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var received []Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Errorf("invalid notification: %v", err)
+		}
+		mu.Lock()
+		received = append(received, n)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	rules := []Rule{{
+		Name:      "degraded",
+		Condition: ConditionVolumeDegraded,
+		For:       Duration(10 * time.Minute),
+		Severity:  "warning",
+	}}
+	evaluator := NewEvaluator(rules, NewWebhookNotifier(server.URL))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshot := func(offset time.Duration, robustness string) metrics.Snapshot {
+		return metrics.Snapshot{
+			Time:    start.Add(offset),
+			Volumes: []client.Volume{{Name: "vol-1", Robustness: robustness}},
+		}
+	}
+
+	steps := []struct {
+		offset     time.Duration
+		robustness string
+		want       []string
+	}{
+		{0, "degraded", nil},
+		{5 * time.Minute, "degraded", nil},
+		{11 * time.Minute, "degraded", []string{StatusFiring}},
+		{12 * time.Minute, "degraded", []string{StatusFiring}},
+		{13 * time.Minute, "healthy", []string{StatusFiring, StatusResolved}},
+		{14 * time.Minute, "healthy", []string{StatusFiring, StatusResolved}},
+	}
+
+	for _, step := range steps {
+		if err := evaluator.Evaluate(snapshot(step.offset, step.robustness)); err != nil {
+			t.Fatalf("Evaluate() at %v error = %v", step.offset, err)
+		}
+
+		mu.Lock()
+		got := make([]string, len(received))
+		for i, n := range received {
+			got[i] = n.Status
+		}
+		mu.Unlock()
+
+		if len(got) != len(step.want) {
+			t.Fatalf("at %v: notifications = %v, want %v", step.offset, got, step.want)
+		}
+		for i := range got {
+			if got[i] != step.want[i] {
+				t.Fatalf("at %v: notifications = %v, want %v", step.offset, got, step.want)
+			}
+		}
+	}
+
+	if received[0].Subject != "volume/vol-1" || !received[0].Since.Equal(start) {
+		t.Errorf("unexpected firing notification: %+v", received[0])
+	}
+}
+
+func TestEvaluatorRetriesFailedWebhook(t *testing.T) {
+	fail := true
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	rules := []Rule{{Name: "not-ready", Condition: ConditionNodeNotReady}}
+	evaluator := NewEvaluator(rules, NewWebhookNotifier(server.URL))
+	snapshot := metrics.Snapshot{Time: time.Now(), Nodes: []client.Node{{Name: "node-1"}}}
+
+	if err := evaluator.Evaluate(snapshot); err == nil {
+		t.Fatalf("expected webhook error")
+	}
+
+	fail = false
+	if err := evaluator.Evaluate(snapshot); err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if err := evaluator.Evaluate(snapshot); err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("webhook calls = %d, want 2", calls)
+	}
+}
+
+func TestCheckBackupAge(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	rule := Rule{Name: "stale-backup", Condition: ConditionBackupAge, MaxAge: Duration(26 * time.Hour)}
+
+	snapshot := metrics.Snapshot{
+		Time: now,
+		Volumes: []client.Volume{
+			{Name: "fresh"},
+			{Name: "stale"},
+			{Name: "never"},
+			{Name: "failed-only"},
+		},
+		Backups: []client.Backup{
+			{VolumeName: "fresh", State: "Completed", Created: "2024-01-02T06:00:00Z"},
+			{VolumeName: "stale", State: "Completed", Created: "2023-12-30T12:00:00Z"},
+			{VolumeName: "failed-only", State: "Error", Created: "2024-01-02T06:00:00Z"},
+		},
+	}
+
+	want := map[string]string{
+		"volume/stale":       "last backup of volume stale is 72h0m0s old",
+		"volume/never":       "volume never has never been backed up",
+		"volume/failed-only": "volume failed-only has never been backed up",
+	}
+
+	got := check(rule, snapshot)
+	if len(got) != len(want) {
+		t.Fatalf("check() = %v, want %v", got, want)
+	}
+	for subject, message := range want {
+		if got[subject] != message {
+			t.Errorf("check()[%q] = %q, want %q", subject, got[subject], message)
+		}
+	}
+}
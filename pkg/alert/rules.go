@@ -0,0 +1,117 @@
+// pkg/alert/rules.go
+package alert
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Conditions that a rule can check
+const (
+	ConditionVolumeDegraded    = "volume-degraded"
+	ConditionVolumeFaulted     = "volume-faulted"
+	ConditionReplicasBelowSpec = "replicas-below-spec"
+	ConditionBackupAge         = "backup-age"
+	ConditionDiskUsage         = "disk-usage"
+	ConditionNodeNotReady      = "node-not-ready"
+)
+
+// Duration is a time.Duration read from strings such as "10m" or "26h"
+type Duration time.Duration
+
+// UnmarshalYAML parses a duration string
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Rule is a condition that fires an alert once it has held for the For
+// duration
+type Rule struct {
+	Name      string   `yaml:"name"`
+	Condition string   `yaml:"condition"`
+	For       Duration `yaml:"for"`
+	Severity  string   `yaml:"severity"`
+
+	// MaxAge is the backup age above which backup-age fires
+	MaxAge Duration `yaml:"maxAge"`
+	// Threshold is the disk usage percentage above which disk-usage fires
+	Threshold float64 `yaml:"threshold"`
+}
+
+// Config is the contents of a rules file
+type Config struct {
+	Webhook  string   `yaml:"webhook"`
+	Interval Duration `yaml:"interval"`
+	Rules    []Rule   `yaml:"rules"`
+}
+
+// LoadConfig reads and validates a rules file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates the contents of a rules file
+func ParseConfig(data []byte) (*Config, error) {
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	if len(config.Rules) == 0 {
+		return nil, fmt.Errorf("no rules defined")
+	}
+
+	names := make(map[string]bool)
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i+1)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if rule.Severity == "" {
+			rule.Severity = "warning"
+		}
+
+		switch rule.Condition {
+		case ConditionVolumeDegraded,
+			ConditionVolumeFaulted,
+			ConditionReplicasBelowSpec,
+			ConditionNodeNotReady:
+		case ConditionBackupAge:
+			if rule.MaxAge <= 0 {
+				return nil, fmt.Errorf("rule %q: maxAge is required for %s", rule.Name, rule.Condition)
+			}
+		case ConditionDiskUsage:
+			if rule.Threshold <= 0 || rule.Threshold > 100 {
+				return nil, fmt.Errorf(
+					"rule %q: threshold must be a percentage between 0 and 100",
+					rule.Name,
+				)
+			}
+		default:
+			return nil, fmt.Errorf("rule %q: unknown condition %q", rule.Name, rule.Condition)
+		}
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,213 @@
+// pkg/bundle/bundle.go
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pascal71/lhcli/pkg/client"
+	"github.com/pascal71/lhcli/pkg/health"
+)
+
+// Files and directories inside a bundle, relative to its root directory
+const (
+	MetadataFile = "metadata.yaml"
+	SummaryFile  = "summary.txt"
+	EventsFile   = "events.yaml"
+	PodsFile     = "pods.yaml"
+	LonghornDir  = "longhorn"
+)
+
+// Resources are the Longhorn resources collected into a bundle, each
+// written to LonghornDir/<resource>.yaml
+var Resources = []string{
+	"volumes",
+	"engines",
+	"replicas",
+	"nodes",
+	"settings",
+	"backups",
+	"backuptargets",
+	"recurringjobs",
+	"instancemanagers",
+	"engineimages",
+	"orphans",
+	"sharemanagers",
+}
+
+// Options control the contents of a bundle
+type Options struct {
+	// Version is the lhcli version recorded in the metadata
+	Version string
+	// Redact removes secrets and IP addresses from the collected data
+	Redact bool
+}
+
+// Metadata describes a bundle
+type Metadata struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Namespace   string    `json:"namespace"`
+	Version     string    `json:"lhcliVersion"`
+	Redacted    bool      `json:"redacted"`
+	Resources   []string  `json:"resources"`
+	// Errors lists what could not be collected
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Generate collects the Longhorn resources, events, pod statuses and a
+// summary report through the Kubernetes API and writes them to w as a
+// gzipped tar archive. Resources that cannot be read are recorded in the
+// metadata instead of failing the bundle.
+func Generate(ctx context.Context, w io.Writer, c *client.Client, opts Options) (*Metadata, error) {
+	now := time.Now().UTC()
+	meta := &Metadata{
+		GeneratedAt: now,
+		Namespace:   c.Namespace(),
+		Version:     opts.Version,
+		Redacted:    opts.Redact,
+	}
+
+	var r *redactor
+	if opts.Redact {
+		r = newRedactor()
+	}
+
+	// The metadata is written last, once all errors are known
+	var files []file
+	add := func(name string, data []byte) {
+		files = append(files, file{name: name, data: data})
+	}
+
+	collect := func(resource, name string, trim func(map[string]interface{})) error {
+		items, err := c.ListRaw(ctx, resource)
+		if err != nil {
+			return err
+		}
+		data, err := marshalList(items, trim, r)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", resource, err)
+		}
+		add(name, data)
+		meta.Resources = append(meta.Resources, resource)
+		return nil
+	}
+
+	for _, resource := range Resources {
+		err := collect(resource, path.Join(LonghornDir, resource+".yaml"), nil)
+		if errors.Is(err, client.ErrKubernetesRequired) {
+			return nil, err
+		}
+		if err != nil {
+			meta.Errors = append(meta.Errors, err.Error())
+		}
+	}
+	if err := collect("events", EventsFile, nil); err != nil {
+		meta.Errors = append(meta.Errors, err.Error())
+	}
+	if err := collect("pods", PodsFile, trimPod); err != nil {
+		meta.Errors = append(meta.Errors, err.Error())
+	}
+
+	summary := Summary(health.NewClientSource(ctx, c), meta)
+	if r != nil {
+		summary = r.text(summary)
+		for i := range meta.Errors {
+			meta.Errors[i] = r.text(meta.Errors[i])
+		}
+	}
+	add(SummaryFile, []byte(summary))
+
+	metadata, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	add(MetadataFile, metadata)
+
+	root := "lhcli-support-bundle-" + now.Format("20060102-150405")
+	if err := writeArchive(w, root, files, now); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// marshalList encodes objects as a YAML v1 List
+func marshalList(
+	items []unstructured.Unstructured,
+	trim func(map[string]interface{}),
+	r *redactor,
+) ([]byte, error) {
+	objects := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		obj := item.Object
+		// Managed fields are large and say nothing about the state
+		unstructured.RemoveNestedField(obj, "metadata", "managedFields")
+		if trim != nil {
+			trim(obj)
+		}
+		if r != nil {
+			r.object(obj)
+		}
+		objects = append(objects, obj)
+	}
+
+	return yaml.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      objects,
+	})
+}
+
+// trimPod keeps the identity, placement and status of a pod. The rest of the
+// spec is dropped, since it can carry secrets in environment variables.
+func trimPod(obj map[string]interface{}) {
+	nodeName, _, _ := unstructured.NestedString(obj, "spec", "nodeName")
+	unstructured.RemoveNestedField(obj, "spec")
+	unstructured.RemoveNestedField(obj, "metadata", "annotations")
+	if nodeName != "" {
+		unstructured.SetNestedField(obj, nodeName, "spec", "nodeName")
+	}
+}
+
+// file is an entry of a bundle archive
+type file struct {
+	name string
+	data []byte
+}
+
+// writeArchive writes the files below the root directory of a gzipped tar
+func writeArchive(w io.Writer, root string, files []file, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, f := range files {
+		header := &tar.Header{
+			Name:    path.Join(root, f.name),
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// pkg/bundle/bundle_test.go
+package bundle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+func longhornObject(kind, name string, spec, status map[string]interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "longhorn.io/v1beta2",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "longhorn-system",
+		},
+		"spec":   spec,
+		"status": status,
+	}}
+}
+
+// TestGenerateAndOpen writes a bundle from a cluster and reads it back
+// through a read-only client
+func TestGenerateAndOpen(t *testing.T) {
+	cluster, err := client.NewReadOnlyClient("longhorn-system", []unstructured.Unstructured{
+		longhornObject("Volume", "vol-1",
+			map[string]interface{}{"size": "10737418240", "numberOfReplicas": int64(2)},
+			map[string]interface{}{"state": "attached", "robustness": "degraded"},
+		),
+		longhornObject("Replica", "vol-1-r-1",
+			map[string]interface{}{"volumeName": "vol-1", "nodeID": "node-1"},
+			map[string]interface{}{"currentState": "running"},
+		),
+		longhornObject("Node", "node-1",
+			map[string]interface{}{"allowScheduling": true},
+			map[string]interface{}{
+				"address": "10.0.0.1",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "True"},
+				},
+			},
+		),
+	})
+	if err != nil {
+		t.Fatalf("NewReadOnlyClient() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, err := Generate(context.Background(), f, cluster, Options{Version: "test", Redact: true})
+	f.Close()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(meta.Errors) != 0 {
+		t.Errorf("collection errors: %v", meta.Errors)
+	}
+
+	c, err := NewClient(path)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	volumes, err := c.Volumes().List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(volumes) != 1 || volumes[0].Robustness != "degraded" || len(volumes[0].Replicas) != 1 {
+		t.Errorf("volumes = %+v, want vol-1 with one replica", volumes)
+	}
+	if volumes[0].NumberOfReplicas != 2 {
+		t.Errorf("numberOfReplicas = %d, want 2", volumes[0].NumberOfReplicas)
+	}
+
+	node, err := c.Nodes().Get(ctx, "node-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if node.Address != "REDACTED-IP-1" {
+		t.Errorf("address = %q, want it redacted", node.Address)
+	}
+
+	if err := c.Volumes().Delete(ctx, "vol-1"); !errors.Is(err, client.ErrReadOnly) {
+		t.Errorf("Delete() error = %v, want %v", err, client.ErrReadOnly)
+	}
+}
@@ -0,0 +1,168 @@
+// pkg/bundle/open.go
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// Bundle is the content of a support bundle
+type Bundle struct {
+	Metadata Metadata
+	// Objects are the Longhorn resources, events and pods
+	Objects []unstructured.Unstructured
+}
+
+// Open reads a support bundle, either the tar.gz archive or a directory it
+// was extracted to
+func Open(bundlePath string) (*Bundle, error) {
+	info, err := os.Stat(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+
+	files := make(map[string][]byte)
+	if info.IsDir() {
+		err = readDir(bundlePath, files)
+	} else {
+		err = readArchive(bundlePath, files)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := files[MetadataFile]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a support bundle: %s not found", bundlePath, MetadataFile)
+	}
+
+	b := &Bundle{}
+	if err := yaml.Unmarshal(data, &b.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", MetadataFile, err)
+	}
+
+	names := []string{EventsFile, PodsFile}
+	for _, resource := range Resources {
+		names = append(names, path.Join(LonghornDir, resource+".yaml"))
+	}
+	for _, name := range names {
+		data, ok := files[name]
+		if !ok {
+			continue
+		}
+		objects, err := unmarshalList(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		b.Objects = append(b.Objects, objects...)
+	}
+
+	return b, nil
+}
+
+// NewClient creates a read-only client over the resources in a bundle
+func NewClient(bundlePath string) (*client.Client, error) {
+	b, err := Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewReadOnlyClient(b.Metadata.Namespace, b.Objects)
+}
+
+// readArchive reads the YAML files of a bundle archive, keyed by their path
+// below the root directory
+func readArchive(archivePath string, files map[string][]byte) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".yaml") {
+			continue
+		}
+
+		// Strip the root directory
+		name := header.Name
+		if i := strings.Index(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		files[name] = data
+	}
+}
+
+// readDir reads the YAML files of an extracted bundle. The directory may be
+// the root directory of the bundle or the directory it was extracted into.
+func readDir(dir string, files map[string][]byte) error {
+	if _, err := os.Stat(filepath.Join(dir, MetadataFile)); err != nil {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*", MetadataFile))
+		if len(matches) == 1 {
+			dir = filepath.Dir(matches[0])
+		}
+	}
+
+	return filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".yaml") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+}
+
+// unmarshalList decodes a YAML v1 List
+func unmarshalList(data []byte) ([]unstructured.Unstructured, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list unstructured.UnstructuredList
+	if err := list.UnmarshalJSON(jsonData); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,92 @@
+// pkg/bundle/redact.go
+package bundle
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+)
+
+// Redacted replaces sensitive values
+const Redacted = "REDACTED"
+
+var (
+	// sensitiveKey matches field and setting names that hold secrets
+	sensitiveKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|access-?key)`)
+	// urlUserinfo matches the credentials in URLs such as s3://key:secret@bucket
+	urlUserinfo = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/@\s]+@`)
+	ipv4        = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	// ipv6 candidates are validated with net.ParseIP, which rejects e.g. times
+	ipv6 = regexp.MustCompile(`[0-9a-fA-F]{0,4}(?::[0-9a-fA-F]{0,4}){2,7}`)
+)
+
+// redactor removes secrets and IP addresses from bundle contents. The same IP
+// address is always replaced by the same placeholder, so that objects can
+// still be related to each other.
+type redactor struct {
+	ips map[string]string
+}
+
+func newRedactor() *redactor {
+	return &redactor{ips: make(map[string]string)}
+}
+
+// text redacts credentials in URLs and IP addresses in a string
+func (r *redactor) text(s string) string {
+	s = urlUserinfo.ReplaceAllString(s, "${1}"+Redacted+"@")
+	s = ipv4.ReplaceAllStringFunc(s, r.ip)
+	s = ipv6.ReplaceAllStringFunc(s, r.ip)
+	return s
+}
+
+// ip returns the placeholder for an IP address, or s if it is not one
+func (r *redactor) ip(s string) string {
+	parsed := net.ParseIP(s)
+	if parsed == nil {
+		return s
+	}
+	key := parsed.String()
+	if placeholder, ok := r.ips[key]; ok {
+		return placeholder
+	}
+	placeholder := fmt.Sprintf("%s-IP-%d", Redacted, len(r.ips)+1)
+	r.ips[key] = placeholder
+	return placeholder
+}
+
+// object redacts an unstructured object in place. Values of sensitive fields
+// are replaced, as are the values of name/value pairs with a sensitive name,
+// such as Longhorn settings and container environment variables.
+func (r *redactor) object(obj map[string]interface{}) {
+	name, _ := obj["name"].(string)
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok && name == "" {
+		name, _ = metadata["name"].(string)
+	}
+	if _, ok := obj["value"]; ok && sensitiveKey.MatchString(name) {
+		obj["value"] = Redacted
+	}
+
+	for key, value := range obj {
+		if sensitiveKey.MatchString(key) {
+			if _, ok := value.(string); ok {
+				obj[key] = Redacted
+				continue
+			}
+		}
+		obj[key] = r.value(value)
+	}
+}
+
+func (r *redactor) value(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.text(v)
+	case map[string]interface{}:
+		r.object(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+	}
+	return value
+}
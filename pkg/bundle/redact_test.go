@@ -0,0 +1,73 @@
+// pkg/bundle/redact_test.go
+package bundle
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactText(t *testing.T) {
+	r := newRedactor()
+
+	got := r.text("replica 10.42.0.15:10000 synced from 10.42.0.16, retry 10.42.0.15")
+	want := "replica REDACTED-IP-1:10000 synced from REDACTED-IP-2, retry REDACTED-IP-1"
+	if got != want {
+		t.Errorf("text() = %q, want %q", got, want)
+	}
+
+	got = r.text("s3://AKIAEXAMPLE:c2VjcmV0@backups/ at 12:34:56 on fd00::1")
+	if strings.Contains(got, "AKIAEXAMPLE") || strings.Contains(got, "fd00::1") {
+		t.Errorf("text() = %q, credentials or IPv6 address not redacted", got)
+	}
+	if !strings.Contains(got, "12:34:56") {
+		t.Errorf("text() = %q, time was redacted", got)
+	}
+
+	// Versions are not addresses
+	if got := r.text("longhornio/longhorn-engine:v1.7.0"); got != "longhornio/longhorn-engine:v1.7.0" {
+		t.Errorf("text() = %q, image was changed", got)
+	}
+}
+
+func TestRedactObject(t *testing.T) {
+	r := newRedactor()
+
+	setting := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "backup-target-credential-secret"},
+		"value":    "s3-secret",
+	}
+	r.object(setting)
+	if setting["value"] != Redacted {
+		t.Errorf("setting value = %v, want %s", setting["value"], Redacted)
+	}
+
+	pod := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "longhorn-manager-abcde"},
+		"status": map[string]interface{}{
+			"podIP": "10.42.1.7",
+			"containerStatuses": []interface{}{
+				map[string]interface{}{"name": "longhorn-manager", "ready": true},
+			},
+		},
+		"env": []interface{}{
+			map[string]interface{}{"name": "AWS_SECRET_ACCESS_KEY", "value": "abc"},
+			map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+		},
+	}
+	r.object(pod)
+
+	status := pod["status"].(map[string]interface{})
+	if status["podIP"] != "REDACTED-IP-1" {
+		t.Errorf("podIP = %v, want REDACTED-IP-1", status["podIP"])
+	}
+	env := pod["env"].([]interface{})
+	if env[0].(map[string]interface{})["value"] != Redacted {
+		t.Errorf("secret environment variable was not redacted")
+	}
+	if env[1].(map[string]interface{})["value"] != "debug" {
+		t.Errorf("plain environment variable was redacted")
+	}
+	if pod["metadata"].(map[string]interface{})["name"] != "longhorn-manager-abcde" {
+		t.Errorf("pod name was changed")
+	}
+}
@@ -0,0 +1,106 @@
+// pkg/bundle/summary.go
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pascal71/lhcli/pkg/health"
+)
+
+// Summary renders the lhcli summary report of a bundle: resource counts and
+// the results of all health checks
+func Summary(src health.Source, meta *Metadata) string {
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "lhcli support bundle")
+	fmt.Fprintf(&b, "Generated:  %s\n", meta.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Namespace:  %s\n", meta.Namespace)
+	fmt.Fprintf(&b, "lhcli:      %s\n", meta.Version)
+	fmt.Fprintln(&b)
+
+	if nodes, err := src.Nodes(); err != nil {
+		fmt.Fprintf(&b, "Nodes:      error: %v\n", err)
+	} else {
+		ready := 0
+		for _, node := range nodes {
+			if health.NodeStatus(node) == "Ready" {
+				ready++
+			}
+		}
+		fmt.Fprintf(&b, "Nodes:      %d (%d ready)\n", len(nodes), ready)
+	}
+
+	if volumes, err := src.Volumes(); err != nil {
+		fmt.Fprintf(&b, "Volumes:    error: %v\n", err)
+	} else {
+		states := make(map[string]int)
+		robustness := make(map[string]int)
+		for _, volume := range volumes {
+			states[strings.ToLower(health.VolumeState(volume))]++
+			if volume.Robustness != "" {
+				robustness[strings.ToLower(volume.Robustness)]++
+			}
+		}
+		fmt.Fprintf(&b, "Volumes:    %d (%s; %s)\n",
+			len(volumes), formatCounts(states), formatCounts(robustness))
+	}
+
+	if replicas, err := src.Replicas(); err != nil {
+		fmt.Fprintf(&b, "Replicas:   error: %v\n", err)
+	} else {
+		fmt.Fprintf(&b, "Replicas:   %d\n", len(replicas))
+	}
+
+	if len(meta.Errors) > 0 {
+		fmt.Fprintln(&b, "\nCollection errors:")
+		for _, err := range meta.Errors {
+			fmt.Fprintf(&b, "  %s\n", err)
+		}
+	}
+
+	report := health.Run(src, health.DefaultChecks())
+	fmt.Fprintf(&b, "\nHealth checks: %s (%d passed, %d warnings, %d failed)\n",
+		report.Status, report.Passed, report.Warnings, report.Failed)
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tMESSAGE")
+	for _, result := range report.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Check, result.Status, result.Message)
+	}
+	tw.Flush()
+
+	for _, result := range report.Results {
+		if len(result.Findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s:\n", result.Check)
+		for _, finding := range result.Findings {
+			fmt.Fprintf(&b, "  - %s: %s\n", finding.Status, finding.Message)
+		}
+	}
+
+	return b.String()
+}
+
+// formatCounts formats counts such as "2 attached, 1 detached"
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%d %s", counts[k], k)
+	}
+	return strings.Join(parts, ", ")
+}
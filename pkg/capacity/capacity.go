@@ -0,0 +1,282 @@
+// pkg/capacity/capacity.go
+package capacity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// Longhorn settings that control replica scheduling
+const (
+	SettingOverProvisioningPercentage = "storage-over-provisioning-percentage"
+	SettingMinimalAvailablePercentage = "storage-minimal-available-percentage"
+	SettingReplicaSoftAntiAffinity    = "replica-soft-anti-affinity"
+	SettingZoneSoftAntiAffinity       = "replica-zone-soft-anti-affinity"
+	SettingDiskSoftAntiAffinity       = "replica-disk-soft-anti-affinity"
+)
+
+// Settings holds the scheduling settings used by the Longhorn scheduler
+type Settings struct {
+	OverProvisioningPercentage int64 `json:"overProvisioningPercentage"`
+	MinimalAvailablePercentage int64 `json:"minimalAvailablePercentage"`
+	ReplicaSoftAntiAffinity    bool  `json:"replicaSoftAntiAffinity"`
+	ZoneSoftAntiAffinity       bool  `json:"replicaZoneSoftAntiAffinity"`
+	DiskSoftAntiAffinity       bool  `json:"replicaDiskSoftAntiAffinity"`
+}
+
+// DefaultSettings returns the Longhorn default settings
+func DefaultSettings() Settings {
+	return Settings{
+		OverProvisioningPercentage: 100,
+		MinimalAvailablePercentage: 25,
+		ReplicaSoftAntiAffinity:    false,
+		ZoneSoftAntiAffinity:       true,
+		DiskSoftAntiAffinity:       true,
+	}
+}
+
+// SettingsFromMap reads the scheduling settings from Longhorn settings, falling back
+// to the defaults for missing or invalid values
+func SettingsFromMap(settings map[string]client.Setting) Settings {
+	result := DefaultSettings()
+	if s, ok := settings[SettingOverProvisioningPercentage]; ok {
+		if v, err := strconv.ParseInt(s.Value, 10, 64); err == nil {
+			result.OverProvisioningPercentage = v
+		}
+	}
+	if s, ok := settings[SettingMinimalAvailablePercentage]; ok {
+		if v, err := strconv.ParseInt(s.Value, 10, 64); err == nil {
+			result.MinimalAvailablePercentage = v
+		}
+	}
+	if s, ok := settings[SettingReplicaSoftAntiAffinity]; ok {
+		if v, err := strconv.ParseBool(s.Value); err == nil {
+			result.ReplicaSoftAntiAffinity = v
+		}
+	}
+	if s, ok := settings[SettingZoneSoftAntiAffinity]; ok {
+		if v, err := strconv.ParseBool(s.Value); err == nil {
+			result.ZoneSoftAntiAffinity = v
+		}
+	}
+	if s, ok := settings[SettingDiskSoftAntiAffinity]; ok {
+		if v, err := strconv.ParseBool(s.Value); err == nil {
+			result.DiskSoftAntiAffinity = v
+		}
+	}
+	return result
+}
+
+// ScheduledLimit returns the maximum storage that may be scheduled on a disk
+func (s Settings) ScheduledLimit(disk client.Disk) int64 {
+	return int64(
+		float64(disk.StorageMaximum-disk.StorageReserved) *
+			float64(s.OverProvisioningPercentage) / 100,
+	)
+}
+
+// MinimalAvailable returns the storage that must stay available on a disk
+func (s Settings) MinimalAvailable(disk client.Disk) int64 {
+	return int64(float64(disk.StorageMaximum) * float64(s.MinimalAvailablePercentage) / 100)
+}
+
+// CheckDisk reports whether a replica of the given size fits on the disk,
+// using the same rules as the Longhorn scheduler. required is the storage
+// the replica will actually consume right away (0 for thin provisioning).
+// The returned reason is empty if the disk is schedulable.
+func (s Settings) CheckDisk(disk client.Disk, size, required int64) (bool, string) {
+	if disk.StorageMaximum <= 0 || disk.StorageAvailable <= 0 {
+		return false, "no storage available"
+	}
+
+	if minimal := s.MinimalAvailable(disk); disk.StorageAvailable-required <= minimal {
+		return false, fmt.Sprintf(
+			"available storage below %d%% minimum",
+			s.MinimalAvailablePercentage,
+		)
+	}
+
+	if limit := s.ScheduledLimit(disk); disk.StorageScheduled+size > limit {
+		return false, fmt.Sprintf(
+			"scheduled storage exceeds %d%% over-provisioning limit",
+			s.OverProvisioningPercentage,
+		)
+	}
+
+	return true, ""
+}
+
+// DiskReport describes the capacity of a single disk
+type DiskReport struct {
+	Node        string   `json:"node"`
+	Zone        string   `json:"zone"`
+	DiskID      string   `json:"diskID"`
+	Path        string   `json:"path"`
+	Tags        []string `json:"tags"`
+	Maximum     int64    `json:"storageMaximum"`
+	Available   int64    `json:"storageAvailable"`
+	Reserved    int64    `json:"storageReserved"`
+	Scheduled   int64    `json:"storageScheduled"`
+	Limit       int64    `json:"scheduledLimit"`
+	Schedulable bool     `json:"schedulable"`
+	Reason      string   `json:"reason,omitempty"`
+}
+
+// Summary aggregates the capacity of a group of disks
+type Summary struct {
+	Name             string `json:"name"`
+	Disks            int    `json:"disks"`
+	SchedulableDisks int    `json:"schedulableDisks"`
+	Maximum          int64  `json:"storageMaximum"`
+	Available        int64  `json:"storageAvailable"`
+	Reserved         int64  `json:"storageReserved"`
+	Scheduled        int64  `json:"storageScheduled"`
+	Limit            int64  `json:"scheduledLimit"`
+}
+
+// Report is the capacity of the whole cluster broken down in several ways
+type Report struct {
+	Settings Settings     `json:"settings"`
+	Disks    []DiskReport `json:"disks"`
+	Nodes    []Summary    `json:"nodes"`
+	Zones    []Summary    `json:"zones"`
+	Tags     []Summary    `json:"tags"`
+	Cluster  Summary      `json:"cluster"`
+}
+
+// NoneLabel groups disks without a zone or tag
+const NoneLabel = "<none>"
+
+// BuildReport computes the capacity report for the given nodes
+func BuildReport(nodes []client.Node, settings Settings) *Report {
+	report := &Report{
+		Settings: settings,
+		Disks:    []DiskReport{},
+		Cluster:  Summary{Name: "cluster"},
+	}
+
+	nodeSummaries := make(map[string]*Summary)
+	zoneSummaries := make(map[string]*Summary)
+	tagSummaries := make(map[string]*Summary)
+
+	for _, node := range nodes {
+		zone := node.Zone
+		if zone == "" {
+			zone = NoneLabel
+		}
+
+		for diskID, disk := range node.Disks {
+			d := DiskReport{
+				Node:      node.Name,
+				Zone:      zone,
+				DiskID:    diskID,
+				Path:      disk.Path,
+				Tags:      disk.Tags,
+				Maximum:   disk.StorageMaximum,
+				Available: disk.StorageAvailable,
+				Reserved:  disk.StorageReserved,
+				Scheduled: disk.StorageScheduled,
+				Limit:     settings.ScheduledLimit(disk),
+			}
+			d.Schedulable, d.Reason = diskSchedulable(node, disk, settings)
+			report.Disks = append(report.Disks, d)
+
+			addToSummary(&report.Cluster, d)
+			addToSummary(summaryFor(nodeSummaries, node.Name), d)
+			addToSummary(summaryFor(zoneSummaries, zone), d)
+			if len(disk.Tags) == 0 {
+				addToSummary(summaryFor(tagSummaries, NoneLabel), d)
+			}
+			for _, tag := range disk.Tags {
+				addToSummary(summaryFor(tagSummaries, tag), d)
+			}
+		}
+	}
+
+	sort.Slice(report.Disks, func(i, j int) bool {
+		if report.Disks[i].Node != report.Disks[j].Node {
+			return report.Disks[i].Node < report.Disks[j].Node
+		}
+		return report.Disks[i].DiskID < report.Disks[j].DiskID
+	})
+
+	report.Nodes = sortedSummaries(nodeSummaries)
+	report.Zones = sortedSummaries(zoneSummaries)
+	report.Tags = sortedSummaries(tagSummaries)
+
+	return report
+}
+
+// diskSchedulable reports whether new replicas can be placed on the disk at all
+func diskSchedulable(node client.Node, disk client.Disk, settings Settings) (bool, string) {
+	if reason := nodeBlocked(node); reason != "" {
+		return false, reason
+	}
+	if reason := diskBlocked(disk); reason != "" {
+		return false, reason
+	}
+	return settings.CheckDisk(disk, 0, 0)
+}
+
+// nodeBlocked returns why no replicas can be scheduled on the node, or ""
+func nodeBlocked(node client.Node) string {
+	if !node.AllowScheduling {
+		return "node scheduling disabled"
+	}
+	if node.EvictionRequested {
+		return "node eviction requested"
+	}
+	if cond, ok := node.Conditions["Ready"]; ok && cond.Status != "True" {
+		return "node not ready"
+	}
+	return ""
+}
+
+// diskBlocked returns why no replicas can be scheduled on the disk, or ""
+func diskBlocked(disk client.Disk) string {
+	if !disk.AllowScheduling {
+		return "disk scheduling disabled"
+	}
+	if disk.EvictionRequested {
+		return "disk eviction requested"
+	}
+	if cond, ok := disk.Conditions["Ready"]; ok && cond.Status != "True" {
+		return "disk not ready"
+	}
+	return ""
+}
+
+func summaryFor(summaries map[string]*Summary, name string) *Summary {
+	s, ok := summaries[name]
+	if !ok {
+		s = &Summary{Name: name}
+		summaries[name] = s
+	}
+	return s
+}
+
+func addToSummary(s *Summary, d DiskReport) {
+	s.Disks++
+	if d.Schedulable {
+		s.SchedulableDisks++
+	}
+	s.Maximum += d.Maximum
+	s.Available += d.Available
+	s.Reserved += d.Reserved
+	s.Scheduled += d.Scheduled
+	s.Limit += d.Limit
+}
+
+func sortedSummaries(summaries map[string]*Summary) []Summary {
+	result := make([]Summary, 0, len(summaries))
+	for _, s := range summaries {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,185 @@
+// pkg/capacity/capacity_test.go
+package capacity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+const gi = int64(1024 * 1024 * 1024)
+
+func TestCheckDisk(t *testing.T) {
+	settings := DefaultSettings()
+
+	tests := []struct {
+		name        string
+		disk        client.Disk
+		size        int64
+		schedulable bool
+	}{
+		{
+			name: "plenty of space",
+			disk: client.Disk{
+				StorageMaximum:   100 * gi,
+				StorageAvailable: 80 * gi,
+				StorageScheduled: 10 * gi,
+			},
+			size:        10 * gi,
+			schedulable: true,
+		},
+		{
+			name: "below minimal available",
+			disk: client.Disk{
+				StorageMaximum:   100 * gi,
+				StorageAvailable: 20 * gi,
+			},
+			schedulable: false,
+		},
+		{
+			name: "over-provisioned",
+			disk: client.Disk{
+				StorageMaximum:   100 * gi,
+				StorageAvailable: 80 * gi,
+				StorageReserved:  30 * gi,
+				StorageScheduled: 60 * gi,
+			},
+			size:        20 * gi,
+			schedulable: false,
+		},
+		{
+			name:        "no storage",
+			disk:        client.Disk{},
+			schedulable: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, reason := settings.CheckDisk(test.disk, test.size, 0)
+			if ok != test.schedulable {
+				t.Errorf("CheckDisk() = %v (%s), want %v", ok, reason, test.schedulable)
+			}
+			if !ok && reason == "" {
+				t.Errorf("expected a reason for an unschedulable disk")
+			}
+		})
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	nodes := []client.Node{
+		{
+			Name:            "node-1",
+			Zone:            "zone-a",
+			AllowScheduling: true,
+			Disks: map[string]client.Disk{
+				"disk-1": {
+					AllowScheduling:  true,
+					StorageMaximum:   100 * gi,
+					StorageAvailable: 80 * gi,
+					StorageScheduled: 50 * gi,
+					Tags:             []string{"ssd"},
+				},
+			},
+		},
+		{
+			Name:            "node-2",
+			AllowScheduling: false,
+			Disks: map[string]client.Disk{
+				"disk-1": {
+					AllowScheduling:  true,
+					StorageMaximum:   200 * gi,
+					StorageAvailable: 150 * gi,
+				},
+			},
+		},
+	}
+
+	report := BuildReport(nodes, DefaultSettings())
+
+	if len(report.Disks) != 2 {
+		t.Fatalf("expected 2 disks, got %d", len(report.Disks))
+	}
+	if !report.Disks[0].Schedulable {
+		t.Errorf("expected node-1 disk to be schedulable, got reason %q", report.Disks[0].Reason)
+	}
+	if report.Disks[1].Schedulable {
+		t.Errorf("expected node-2 disk to be unschedulable")
+	}
+
+	if report.Cluster.Maximum != 300*gi {
+		t.Errorf("cluster maximum = %d, want %d", report.Cluster.Maximum, 300*gi)
+	}
+	if report.Cluster.SchedulableDisks != 1 {
+		t.Errorf("cluster schedulable disks = %d, want 1", report.Cluster.SchedulableDisks)
+	}
+
+	if len(report.Zones) != 2 || report.Zones[0].Name != NoneLabel {
+		t.Errorf("unexpected zones: %+v", report.Zones)
+	}
+	if len(report.Tags) != 2 || report.Tags[1].Name != "ssd" {
+		t.Errorf("unexpected tags: %+v", report.Tags)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	disk := func(tags ...string) map[string]client.Disk {
+		return map[string]client.Disk{
+			"disk-1": {
+				AllowScheduling:  true,
+				StorageMaximum:   100 * gi,
+				StorageAvailable: 90 * gi,
+				Tags:             tags,
+			},
+		}
+	}
+	nodes := []client.Node{
+		{Name: "node-1", Zone: "zone-a", AllowScheduling: true, Disks: disk("ssd")},
+		{Name: "node-2", Zone: "zone-b", AllowScheduling: true, Disks: disk("ssd")},
+		{Name: "node-3", Zone: "zone-b", AllowScheduling: true, Disks: disk("hdd")},
+	}
+
+	t.Run("Scheduled", func(t *testing.T) {
+		result := Simulate(nodes, DefaultSettings(), SimulationInput{Size: 10 * gi, Replicas: 3})
+		if !result.Scheduled {
+			t.Fatalf("expected volume to be scheduled, failures: %v", result.Failures)
+		}
+		seen := make(map[string]bool)
+		for _, p := range result.Placements {
+			if seen[p.Node] {
+				t.Errorf("node %s received more than one replica", p.Node)
+			}
+			seen[p.Node] = true
+		}
+	})
+
+	t.Run("NodeAntiAffinity", func(t *testing.T) {
+		result := Simulate(nodes, DefaultSettings(), SimulationInput{
+			Size:         10 * gi,
+			Replicas:     3,
+			DiskSelector: []string{"ssd"},
+		})
+		if result.Scheduled {
+			t.Fatalf("expected scheduling to fail")
+		}
+		if len(result.Placements) != 2 {
+			t.Errorf("expected 2 placements, got %d", len(result.Placements))
+		}
+		if len(result.Failures) != 1 ||
+			!strings.Contains(result.Failures[0], ConstraintNodeAffinity) {
+			t.Errorf("expected node anti-affinity failure, got %v", result.Failures)
+		}
+	})
+
+	t.Run("Space", func(t *testing.T) {
+		result := Simulate(nodes, DefaultSettings(), SimulationInput{Size: 500 * gi, Replicas: 1})
+		if result.Scheduled {
+			t.Fatalf("expected scheduling to fail")
+		}
+		if len(result.Failures) != 1 || !strings.Contains(result.Failures[0], ConstraintSpace) {
+			t.Errorf("expected space failure, got %v", result.Failures)
+		}
+	})
+}
@@ -0,0 +1,288 @@
+// pkg/capacity/simulate.go
+package capacity
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// Constraint categories that can block replica placement
+const (
+	ConstraintScheduling   = "scheduling"
+	ConstraintTags         = "tags"
+	ConstraintSpace        = "space"
+	ConstraintNodeAffinity = "node anti-affinity"
+	ConstraintZoneAffinity = "zone anti-affinity"
+	ConstraintDiskAffinity = "disk anti-affinity"
+)
+
+// SimulationInput describes a prospective volume
+type SimulationInput struct {
+	Size         int64    `json:"size"`
+	Replicas     int      `json:"replicas"`
+	NodeSelector []string `json:"nodeSelector"`
+	DiskSelector []string `json:"diskSelector"`
+}
+
+// Candidate is a disk considered for replica placement
+type Candidate struct {
+	Node       string `json:"node"`
+	Zone       string `json:"zone"`
+	DiskID     string `json:"diskID"`
+	Path       string `json:"path"`
+	Eligible   bool   `json:"eligible"`
+	Constraint string `json:"constraint,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// Placement is the disk chosen for a replica
+type Placement struct {
+	Replica int    `json:"replica"`
+	Node    string `json:"node"`
+	Zone    string `json:"zone"`
+	DiskID  string `json:"diskID"`
+	Path    string `json:"path"`
+}
+
+// SimulationResult is the outcome of a scheduling simulation
+type SimulationResult struct {
+	Input      SimulationInput `json:"input"`
+	Settings   Settings        `json:"settings"`
+	Candidates []Candidate     `json:"candidates"`
+	Placements []Placement     `json:"placements"`
+	Scheduled  bool            `json:"scheduled"`
+	Failures   []string        `json:"failures,omitempty"`
+}
+
+// candidateDisk tracks the state of an eligible disk while placing replicas
+type candidateDisk struct {
+	candidate Candidate
+	disk      client.Disk
+}
+
+// Simulate places the replicas of a prospective volume on the given nodes
+// following the rules of the Longhorn replica scheduler: node and disk tag
+// selectors, storage thresholds and node, zone and disk anti-affinity.
+func Simulate(nodes []client.Node, settings Settings, input SimulationInput) *SimulationResult {
+	result := &SimulationResult{
+		Input:      input,
+		Settings:   settings,
+		Candidates: []Candidate{},
+		Placements: []Placement{},
+	}
+
+	var eligible []*candidateDisk
+	for _, node := range nodes {
+		zone := node.Zone
+		if zone == "" {
+			zone = NoneLabel
+		}
+
+		for diskID, disk := range node.Disks {
+			c := Candidate{
+				Node:   node.Name,
+				Zone:   zone,
+				DiskID: diskID,
+				Path:   disk.Path,
+			}
+
+			switch {
+			case nodeBlocked(node) != "":
+				c.Constraint, c.Reason = ConstraintScheduling, nodeBlocked(node)
+			case !hasAllTags(node.Tags, input.NodeSelector):
+				c.Constraint, c.Reason = ConstraintTags, "node tags do not match node selector"
+			case diskBlocked(disk) != "":
+				c.Constraint, c.Reason = ConstraintScheduling, diskBlocked(disk)
+			case !hasAllTags(disk.Tags, input.DiskSelector):
+				c.Constraint, c.Reason = ConstraintTags, "disk tags do not match disk selector"
+			default:
+				if ok, reason := settings.CheckDisk(disk, input.Size, 0); !ok {
+					c.Constraint, c.Reason = ConstraintSpace, reason
+				} else {
+					c.Eligible = true
+				}
+			}
+
+			result.Candidates = append(result.Candidates, c)
+			if c.Eligible {
+				eligible = append(eligible, &candidateDisk{candidate: c, disk: disk})
+			}
+		}
+	}
+
+	sort.Slice(result.Candidates, func(i, j int) bool {
+		if result.Candidates[i].Node != result.Candidates[j].Node {
+			return result.Candidates[i].Node < result.Candidates[j].Node
+		}
+		return result.Candidates[i].DiskID < result.Candidates[j].DiskID
+	})
+
+	// Prefer the disks with the most available storage, like the Longhorn scheduler
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return eligible[i].disk.StorageAvailable > eligible[j].disk.StorageAvailable
+	})
+
+	if len(eligible) == 0 {
+		if input.Replicas > 0 {
+			result.Failures = append(result.Failures, summarizeRejections(result.Candidates))
+		}
+		return result
+	}
+
+	usedNodes := make(map[string]bool)
+	usedZones := make(map[string]bool)
+	usedDisks := make(map[*candidateDisk]bool)
+
+	for replica := 1; replica <= input.Replicas; replica++ {
+		best, reason := pickDisk(eligible, settings, input.Size, usedNodes, usedZones, usedDisks)
+		if best == nil {
+			result.Failures = append(result.Failures,
+				fmt.Sprintf("replica %d: %s", replica, reason))
+			continue
+		}
+
+		usedNodes[best.candidate.Node] = true
+		usedZones[best.candidate.Zone] = true
+		usedDisks[best] = true
+		best.disk.StorageScheduled += input.Size
+
+		result.Placements = append(result.Placements, Placement{
+			Replica: replica,
+			Node:    best.candidate.Node,
+			Zone:    best.candidate.Zone,
+			DiskID:  best.candidate.DiskID,
+			Path:    best.candidate.Path,
+		})
+	}
+
+	result.Scheduled = len(result.Placements) == input.Replicas
+	return result
+}
+
+// pickDisk chooses the disk for the next replica. Disks in a new zone are
+// preferred over disks on a new node, which are preferred over new disks on
+// a used node. Hard anti-affinity rules exclude candidates entirely.
+func pickDisk(
+	eligible []*candidateDisk,
+	settings Settings,
+	size int64,
+	usedNodes, usedZones map[string]bool,
+	usedDisks map[*candidateDisk]bool,
+) (*candidateDisk, string) {
+	var best *candidateDisk
+	bestScore := -1
+	blocked := make(map[string]int)
+
+	for _, d := range eligible {
+		if ok, _ := settings.CheckDisk(d.disk, size, 0); !ok {
+			blocked[ConstraintSpace]++
+			continue
+		}
+
+		newZone := !usedZones[d.candidate.Zone]
+		newNode := !usedNodes[d.candidate.Node]
+		newDisk := !usedDisks[d]
+
+		switch {
+		case !newDisk && !settings.DiskSoftAntiAffinity:
+			blocked[ConstraintDiskAffinity]++
+			continue
+		case !newNode && !settings.ReplicaSoftAntiAffinity:
+			blocked[ConstraintNodeAffinity]++
+			continue
+		case !newZone && !settings.ZoneSoftAntiAffinity:
+			blocked[ConstraintZoneAffinity]++
+			continue
+		}
+
+		score := 0
+		if newZone {
+			score += 4
+		}
+		if newNode {
+			score += 2
+		}
+		if newDisk {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = d, score
+		}
+	}
+
+	if best != nil {
+		return best, ""
+	}
+
+	// Explain the constraint that blocked the most candidates
+	reason, count := "", 0
+	for _, constraint := range []string{
+		ConstraintNodeAffinity,
+		ConstraintZoneAffinity,
+		ConstraintDiskAffinity,
+		ConstraintSpace,
+	} {
+		if blocked[constraint] > count {
+			reason, count = constraint, blocked[constraint]
+		}
+	}
+	switch reason {
+	case ConstraintNodeAffinity:
+		return nil, fmt.Sprintf(
+			"blocked by %s: every eligible node already holds a replica", reason)
+	case ConstraintZoneAffinity:
+		return nil, fmt.Sprintf(
+			"blocked by %s: every eligible zone already holds a replica", reason)
+	case ConstraintDiskAffinity:
+		return nil, fmt.Sprintf(
+			"blocked by %s: every eligible disk already holds a replica", reason)
+	default:
+		return nil, "blocked by space: no eligible disk has room for another replica"
+	}
+}
+
+// summarizeRejections explains why no disk was eligible at all
+func summarizeRejections(candidates []Candidate) string {
+	if len(candidates) == 0 {
+		return "no disks found on any node"
+	}
+
+	counts := make(map[string]int)
+	for _, c := range candidates {
+		counts[c.Constraint]++
+	}
+
+	constraints := make([]string, 0, len(counts))
+	for constraint := range counts {
+		constraints = append(constraints, constraint)
+	}
+	sort.Strings(constraints)
+
+	summary := "no eligible disks:"
+	for i, constraint := range constraints {
+		if i > 0 {
+			summary += ","
+		}
+		summary += fmt.Sprintf(" %d rejected by %s", counts[constraint], constraint)
+	}
+	return summary
+}
+
+// hasAllTags reports whether tags contains every selector tag
+func hasAllTags(tags, selector []string) bool {
+	for _, want := range selector {
+		found := false
+		for _, tag := range tags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
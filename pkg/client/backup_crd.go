@@ -0,0 +1,240 @@
+// pkg/client/backup_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultBackupTarget is the name of the backup target CRD Longhorn creates
+const defaultBackupTarget = "default"
+
+// backupClient implementation for CRDs
+type crdBackupClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns all backups, or the backups of a volume if volumeName is set
+func (c *crdBackupClient) List(ctx context.Context, volumeName string) ([]Backup, error) {
+	debugLog("Listing Longhorn backups via CRD")
+
+	opts := metav1.ListOptions{}
+	if volumeName != "" {
+		opts.LabelSelector = "backup-volume=" + volumeName
+	}
+
+	list, err := c.crdClient.dynamicClient.Resource(backupGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	backups := make([]Backup, 0, len(list.Items))
+	for _, item := range list.Items {
+		backups = append(backups, *unstructuredToBackup(&item))
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Created < backups[j].Created
+	})
+
+	return backups, nil
+}
+
+// Get returns a specific backup
+func (c *crdBackupClient) Get(ctx context.Context, backupName string) (*Backup, error) {
+	debugLog("Getting Longhorn backup %s via CRD", backupName)
+
+	unstructuredBackup, err := c.crdClient.dynamicClient.Resource(backupGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, backupName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup %s: %w", backupName, err)
+	}
+
+	return unstructuredToBackup(unstructuredBackup), nil
+}
+
+// Create requests a backup of a volume snapshot
+func (c *crdBackupClient) Create(
+	ctx context.Context,
+	volumeName string,
+	input *BackupCreateInput,
+) (*Backup, error) {
+	debugLog("Creating Longhorn backup of volume %s via CRD", volumeName)
+
+	if input == nil || input.SnapshotName == "" {
+		return nil, fmt.Errorf("snapshot name is required")
+	}
+
+	labels := make(map[string]interface{}, len(input.Labels))
+	for k, v := range input.Labels {
+		labels[k] = v
+	}
+
+	backup := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "longhorn.io/v1beta2",
+			"kind":       "Backup",
+			"metadata": map[string]interface{}{
+				"generateName": "backup-",
+				"namespace":    c.crdClient.namespace,
+				"labels": map[string]interface{}{
+					"backup-volume": volumeName,
+				},
+			},
+			"spec": map[string]interface{}{
+				"snapshotName": input.SnapshotName,
+				"labels":       labels,
+			},
+		},
+	}
+
+	created, err := c.crdClient.dynamicClient.Resource(backupGVR).
+		Namespace(c.crdClient.namespace).
+		Create(ctx, backup, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup of volume %s: %w", volumeName, err)
+	}
+
+	return unstructuredToBackup(created), nil
+}
+
+// Delete deletes a backup
+func (c *crdBackupClient) Delete(ctx context.Context, backupName string) error {
+	debugLog("Deleting Longhorn backup %s via CRD", backupName)
+
+	err := c.crdClient.dynamicClient.Resource(backupGVR).
+		Namespace(c.crdClient.namespace).
+		Delete(ctx, backupName, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete backup %s: %w", backupName, err)
+	}
+
+	return nil
+}
+
+// GetTarget returns the default backup target
+func (c *crdBackupClient) GetTarget(ctx context.Context) (*BackupTarget, error) {
+	debugLog("Getting Longhorn backup target via CRD")
+
+	unstructuredTarget, err := c.crdClient.dynamicClient.Resource(backupTargetGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, defaultBackupTarget, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup target: %w", err)
+	}
+
+	return unstructuredToBackupTarget(unstructuredTarget), nil
+}
+
+// SetTarget changes the URL and credential secret of the default backup target
+func (c *crdBackupClient) SetTarget(ctx context.Context, target *BackupTarget) error {
+	debugLog("Updating Longhorn backup target via CRD")
+
+	spec := map[string]interface{}{
+		"backupTargetURL":  target.BackupTargetURL,
+		"credentialSecret": target.CredentialSecret,
+	}
+	mutate := func(current *unstructured.Unstructured) error {
+		for field, value := range spec {
+			if err := unstructured.SetNestedField(current.Object, value, "spec", field); err != nil {
+				return fmt.Errorf("failed to set %s: %w", field, err)
+			}
+		}
+		return nil
+	}
+
+	_, err := c.crdClient.updateWithRetry(ctx, backupTargetGVR, defaultBackupTarget, mutate)
+	if err != nil {
+		return fmt.Errorf("failed to update backup target: %w", err)
+	}
+
+	return nil
+}
+
+// unstructuredToBackup converts a Longhorn backup CRD
+func unstructuredToBackup(u *unstructured.Unstructured) *Backup {
+	status, _, _ := unstructured.NestedMap(u.Object, "status")
+
+	backup := &Backup{
+		Name: u.GetName(),
+	}
+
+	if v, ok := status["state"].(string); ok {
+		backup.State = v
+	}
+	if v, ok := status["progress"].(int64); ok {
+		backup.Progress = int(v)
+	} else if v, ok := status["progress"].(float64); ok {
+		backup.Progress = int(v)
+	}
+	if v, ok := status["error"].(string); ok {
+		backup.Error = v
+	}
+	if v, ok := status["url"].(string); ok {
+		backup.URL = v
+	}
+	if v, ok := status["snapshotName"].(string); ok {
+		backup.SnapshotName = v
+	}
+	if v, ok := status["snapshotCreatedAt"].(string); ok {
+		backup.SnapshotCreated = v
+	}
+	if v, ok := status["backupCreatedAt"].(string); ok {
+		backup.Created = v
+	}
+	if v, ok := status["size"].(string); ok {
+		backup.Size = v
+	}
+	if v, ok := status["volumeName"].(string); ok {
+		backup.VolumeName = v
+	}
+	if v, ok := status["volumeSize"].(string); ok {
+		backup.VolumeSize = v
+	}
+	if v, ok := status["volumeCreated"].(string); ok {
+		backup.VolumeCreated = v
+	}
+	backup.Labels = stringMap(status["labels"])
+
+	// Backups that are still in progress only carry the volume in a label
+	if backup.VolumeName == "" {
+		backup.VolumeName = u.GetLabels()["backup-volume"]
+	}
+
+	return backup
+}
+
+// unstructuredToBackupTarget converts a Longhorn backup target CRD
+func unstructuredToBackupTarget(u *unstructured.Unstructured) *BackupTarget {
+	target := &BackupTarget{}
+
+	if spec, found, err := unstructured.NestedMap(u.Object, "spec"); err == nil && found {
+		if v, ok := spec["backupTargetURL"].(string); ok {
+			target.BackupTargetURL = v
+		}
+		if v, ok := spec["credentialSecret"].(string); ok {
+			target.CredentialSecret = v
+		}
+	}
+
+	if status, found, err := unstructured.NestedMap(u.Object, "status"); err == nil && found {
+		if v, ok := status["available"].(bool); ok {
+			target.Available = v
+		}
+		// The reason a target is unavailable is reported as a condition
+		if conditions, ok := status["conditions"].([]interface{}); ok {
+			if cond, ok := unstructuredConditions(conditions)["Unavailable"]; ok &&
+				cond.Status == "True" {
+				target.Message = cond.Message
+			}
+		}
+	}
+
+	return target
+}
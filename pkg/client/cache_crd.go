@@ -0,0 +1,164 @@
+// pkg/client/cache_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ResourceCache keeps Longhorn resources in memory using informers, so that
+// long running commands can read them without querying the API server
+type ResourceCache struct {
+	factory   dynamicinformer.DynamicSharedInformerFactory
+	informers map[schema.GroupVersionResource]cache.SharedIndexInformer
+}
+
+// NewResourceCache creates a cache of volumes, replicas, nodes and backups.
+// It requires a Kubernetes connection and returns ErrWatchNotSupported otherwise.
+func (c *Client) NewResourceCache() (*ResourceCache, error) {
+	if c.crdClient == nil {
+		return nil, ErrWatchNotSupported
+	}
+	return newResourceCache(c.crdClient, volumeGVR, replicaGVR, nodeGVR, backupGVR), nil
+}
+
+func newResourceCache(
+	crdClient *LonghornCRDClient,
+	resources ...schema.GroupVersionResource,
+) *ResourceCache {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+		crdClient.watchClient,
+		0,
+		crdClient.namespace,
+		nil,
+	)
+
+	informers := make(map[schema.GroupVersionResource]cache.SharedIndexInformer)
+	for _, gvr := range resources {
+		informers[gvr] = factory.ForResource(gvr).Informer()
+	}
+
+	return &ResourceCache{factory: factory, informers: informers}
+}
+
+// OnChange registers a function that is called whenever a cached resource is
+// added, updated or deleted. It must be called before Start.
+func (r *ResourceCache) OnChange(fn func()) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { fn() },
+		UpdateFunc: func(interface{}, interface{}) { fn() },
+		DeleteFunc: func(interface{}) { fn() },
+	}
+	for gvr, informer := range r.informers {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", gvr.Resource, err)
+		}
+	}
+	return nil
+}
+
+// Start starts the informers and waits until the cache is filled. The
+// informers keep running until ctx is cancelled.
+func (r *ResourceCache) Start(ctx context.Context) error {
+	r.factory.Start(ctx.Done())
+
+	synced := make([]cache.InformerSynced, 0, len(r.informers))
+	for _, informer := range r.informers {
+		synced = append(synced, informer.HasSynced)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), synced...) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to sync resource cache")
+	}
+	return nil
+}
+
+// objects returns the cached objects of a resource
+func (r *ResourceCache) objects(gvr schema.GroupVersionResource) []*unstructured.Unstructured {
+	informer, ok := r.informers[gvr]
+	if !ok {
+		return nil
+	}
+
+	items := informer.GetStore().List()
+	objects := make([]*unstructured.Unstructured, 0, len(items))
+	for _, item := range items {
+		if u, ok := item.(*unstructured.Unstructured); ok {
+			objects = append(objects, u)
+		}
+	}
+	return objects
+}
+
+// Volumes returns the cached volumes with their replicas, sorted by name
+func (r *ResourceCache) Volumes() []Volume {
+	volumeReplicas := make(map[string][]Replica)
+	for _, u := range r.objects(replicaGVR) {
+		replica, volumeName, err := unstructuredToReplica(u)
+		if err != nil {
+			debugLog("Failed to convert replica %s: %v", u.GetName(), err)
+			continue
+		}
+		if volumeName != "" {
+			volumeReplicas[volumeName] = append(volumeReplicas[volumeName], *replica)
+		}
+	}
+
+	objects := r.objects(volumeGVR)
+	volumes := make([]Volume, 0, len(objects))
+	for _, u := range objects {
+		volume, err := unstructuredToVolume(u)
+		if err != nil {
+			debugLog("Failed to convert volume %s: %v", u.GetName(), err)
+			continue
+		}
+		volume.Replicas = volumeReplicas[volume.Name]
+		volumes = append(volumes, *volume)
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	return volumes
+}
+
+// Nodes returns the cached nodes, sorted by name
+func (r *ResourceCache) Nodes() []Node {
+	objects := r.objects(nodeGVR)
+	nodes := make([]Node, 0, len(objects))
+	for _, u := range objects {
+		node, err := unstructuredToNode(u)
+		if err != nil {
+			debugLog("Failed to convert node %s: %v", u.GetName(), err)
+			continue
+		}
+		nodes = append(nodes, *node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
+// Backups returns the cached backups, sorted by name
+func (r *ResourceCache) Backups() []Backup {
+	objects := r.objects(backupGVR)
+	backups := make([]Backup, 0, len(objects))
+	for _, u := range objects {
+		backups = append(backups, *unstructuredToBackup(u))
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name < backups[j].Name
+	})
+	return backups
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,6 +21,7 @@ type Config struct {
 	Token     string
 	Timeout   time.Duration
 	Insecure  bool
+	Retry     *RetryPolicy // nil uses DefaultRetryPolicy
 }
 
 // Client is the Longhorn API client
@@ -39,17 +41,21 @@ func NewClient(config *Config) (*Client, error) {
 	// Ensure endpoint doesn't have trailing slash
 	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
 
-	httpClient := &http.Client{
-		Timeout: config.Timeout,
-	}
+	var transport http.RoundTripper = http.DefaultTransport
 
 	// For development/testing with self-signed certificates
 	if config.Insecure {
-		httpClient.Transport = &http.Transport{
+		transport = &http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 		}
 	}
 
+	// The timeout covers retries as well
+	httpClient := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: newRetryTransport(transport, retryPolicyOrDefault(config.Retry)),
+	}
+
 	return &Client{
 		config:     config,
 		httpClient: httpClient,
@@ -58,7 +64,11 @@ func NewClient(config *Config) (*Client, error) {
 }
 
 // doRequest performs an HTTP request
-func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) doRequest(
+	ctx context.Context,
+	method, path string,
+	body interface{},
+) (*http.Response, error) {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -69,7 +79,7 @@ func (c *Client) doRequest(method, path string, body interface{}) (*http.Respons
 	}
 
 	url := c.baseURL + path
-	req, err := http.NewRequest(method, url, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -94,7 +104,11 @@ func (c *Client) doRequest(method, path string, body interface{}) (*http.Respons
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		// A cancelled request is not a sign of an unavailable server
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+		}
+		return nil, withKind(ErrUnavailable, fmt.Errorf("request failed: %w", err))
 	}
 
 	// Debug response
@@ -103,6 +117,30 @@ func (c *Client) doRequest(method, path string, body interface{}) (*http.Respons
 	return resp, nil
 }
 
+// errorFromResponse returns the error of a failed request as ErrorResponse.
+// Longhorn reports errors as an ErrorResponse body; other bodies become the
+// message.
+func errorFromResponse(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	apiErr := &ErrorResponse{}
+	if err := json.Unmarshal(body, apiErr); err == nil && apiErr.Message != "" {
+		if apiErr.Status == 0 {
+			apiErr.Status = resp.StatusCode
+		}
+		return apiErr
+	}
+
+	apiErr = &ErrorResponse{
+		Status:  resp.StatusCode,
+		Message: strings.TrimSpace(string(body)),
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = "unexpected status code"
+	}
+	return apiErr
+}
+
 // Nodes returns the node interface
 func (c *Client) Nodes() NodeInterface {
 	// If we have a CRD client, use it
@@ -125,87 +163,165 @@ func (c *Client) Volumes() VolumeInterface {
 
 // Settings returns the settings interface
 func (c *Client) Settings() SettingsInterface {
+	// If we have a CRD client, use it
+	if c.crdClient != nil {
+		return &crdSettingsClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
 	return &settingsClient{client: c}
 }
 
 // EngineImages returns the engine image interface
 func (c *Client) EngineImages() EngineImageInterface {
+	// If we have a CRD client, use it
+	if c.crdClient != nil {
+		return &crdEngineImageClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
 	return &engineImageClient{client: c}
 }
 
 // Backups returns the backup interface
 func (c *Client) Backups() BackupInterface {
+	// If we have a CRD client, use it
+	if c.crdClient != nil {
+		return &crdBackupClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
 	return &backupClient{client: c}
 }
 
+// InstanceManagers returns the instance manager interface
+func (c *Client) InstanceManagers() InstanceManagerInterface {
+	// If we have a CRD client, use it
+	if c.crdClient != nil {
+		return &crdInstanceManagerClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
+	return &instanceManagerClient{client: c}
+}
+
+// Orphans returns the orphan interface
+func (c *Client) Orphans() OrphanInterface {
+	// If we have a CRD client, use it
+	if c.crdClient != nil {
+		return &crdOrphanClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
+	return &orphanClient{client: c}
+}
+
+// ShareManagers returns the share manager interface
+func (c *Client) ShareManagers() ShareManagerInterface {
+	// If we have a CRD client, use it
+	if c.crdClient != nil {
+		return &crdShareManagerClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
+	return &shareManagerClient{client: c}
+}
+
 // Events returns the event interface
 func (c *Client) Events() EventInterface {
+	// If we have a CRD client, use the Kubernetes events API
+	if c.crdClient != nil {
+		return &crdEventClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
 	return &eventClient{client: c}
 }
 
 // NodeInterface defines node operations
 type NodeInterface interface {
-	List() ([]Node, error)
-	Get(name string) (*Node, error)
-	Update(name string, update *NodeUpdate) (*Node, error)
-	EnableScheduling(name string) error
-	DisableScheduling(name string) error
-	EvictNode(name string) error
-	AddDisk(nodeName string, disk DiskUpdate) error
-	RemoveDisk(nodeName, diskID string) error
-	UpdateDiskTags(nodeName, diskID string, tags []string) error
-	AddNodeTag(nodeName, tag string) error
-	RemoveNodeTag(nodeName, tag string) error
-	EnableDiskScheduling(nodeName, diskID string) error
-	DisableDiskScheduling(nodeName, diskID string) error
+	List(ctx context.Context) ([]Node, error)
+	Get(ctx context.Context, name string) (*Node, error)
+	Update(ctx context.Context, name string, update *NodeUpdate) (*Node, error)
+	EnableScheduling(ctx context.Context, name string) error
+	DisableScheduling(ctx context.Context, name string) error
+	EvictNode(ctx context.Context, name string) error
+	AddDisk(ctx context.Context, nodeName string, disk DiskUpdate) error
+	RemoveDisk(ctx context.Context, nodeName, diskID string, opts DiskRemoveOptions) error
+	UpdateDiskTags(ctx context.Context, nodeName, diskID string, tags []string) error
+	AddNodeTag(ctx context.Context, nodeName, tag string) error
+	RemoveNodeTag(ctx context.Context, nodeName, tag string) error
+	EnableDiskScheduling(ctx context.Context, nodeName, diskID string) error
+	DisableDiskScheduling(ctx context.Context, nodeName, diskID string) error
+	EvictDisk(ctx context.Context, nodeName, diskID string) error
 }
 
 // VolumeInterface defines volume operations
 type VolumeInterface interface {
-	List() ([]Volume, error)
-	Get(name string) (*Volume, error)
-	Create(volume *VolumeCreateInput) (*Volume, error)
-	Delete(name string) error
-	Update(name string, volume *VolumeUpdateInput) (*Volume, error)
-	Attach(name string, input *VolumeAttachInput) (*Volume, error)
-	Detach(name string) error
+	List(ctx context.Context) ([]Volume, error)
+	Get(ctx context.Context, name string) (*Volume, error)
+	Create(ctx context.Context, volume *VolumeCreateInput) (*Volume, error)
+	Delete(ctx context.Context, name string) error
+	Update(ctx context.Context, name string, volume *VolumeUpdateInput) (*Volume, error)
+	Attach(ctx context.Context, name string, input *VolumeAttachInput) (*Volume, error)
+	Detach(ctx context.Context, name string) error
+	Watch(ctx context.Context, callback func([]Volume)) error
 }
 
 // SettingsInterface defines settings operations
 type SettingsInterface interface {
-	List() (map[string]Setting, error)
-	Get(name string) (*Setting, error)
-	Update(name string, value string) (*Setting, error)
+	List(ctx context.Context) (map[string]Setting, error)
+	Get(ctx context.Context, name string) (*Setting, error)
+	Update(ctx context.Context, name string, value string) (*Setting, error)
 }
 
 // BackupInterface defines backup operations
 type BackupInterface interface {
-	List(volumeName string) ([]Backup, error)
-	Get(backupName string) (*Backup, error)
-	Create(volumeName string, input *BackupCreateInput) (*Backup, error)
-	Delete(backupName string) error
-	GetTarget() (*BackupTarget, error)
-	SetTarget(target *BackupTarget) error
+	List(ctx context.Context, volumeName string) ([]Backup, error)
+	Get(ctx context.Context, backupName string) (*Backup, error)
+	Create(ctx context.Context, volumeName string, input *BackupCreateInput) (*Backup, error)
+	Delete(ctx context.Context, backupName string) error
+	GetTarget(ctx context.Context) (*BackupTarget, error)
+	SetTarget(ctx context.Context, target *BackupTarget) error
 }
 
 // EngineImageInterface defines engine image operations
 type EngineImageInterface interface {
-	List() ([]EngineImage, error)
-	Get(name string) (*EngineImage, error)
-	Delete(name string) error
+	List(ctx context.Context) ([]EngineImage, error)
+	Get(ctx context.Context, name string) (*EngineImage, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// InstanceManagerInterface defines instance manager operations
+type InstanceManagerInterface interface {
+	List(ctx context.Context) ([]InstanceManager, error)
+	Get(ctx context.Context, name string) (*InstanceManager, error)
+}
+
+// OrphanInterface defines orphaned data operations
+type OrphanInterface interface {
+	List(ctx context.Context) ([]Orphan, error)
+	Get(ctx context.Context, name string) (*Orphan, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// ShareManagerInterface defines share manager operations
+type ShareManagerInterface interface {
+	List(ctx context.Context) ([]ShareManager, error)
+	Get(ctx context.Context, name string) (*ShareManager, error)
+	Restart(ctx context.Context, name string) error
 }
 
 // EventInterface defines event operations
 type EventInterface interface {
-	List(opts EventListOptions) ([]Event, error)
+	List(ctx context.Context, opts EventListOptions) ([]Event, error)
 	Watch(ctx context.Context, opts EventListOptions, callback func(Event)) error
 }
 
+// EngineInterface defines engine operations
+type EngineInterface interface {
+	List(ctx context.Context) ([]Engine, error)
+	Get(ctx context.Context, name string) (*Engine, error)
+}
+
 // ReplicaInterface defines replica operations
 type ReplicaInterface interface {
-	List() ([]Replica, error)
-	Get(name string) (*Replica, error)
-	Delete(name string) error
+	List(ctx context.Context) ([]Replica, error)
+	Get(ctx context.Context, name string) (*Replica, error)
+	Delete(ctx context.Context, name string) error
 }
 
 // Replicas returns the replica interface
@@ -217,3 +333,13 @@ func (c *Client) Replicas() ReplicaInterface {
 	// Otherwise use the HTTP client
 	return &replicaClient{client: c}
 }
+
+// Engines returns the engine interface
+func (c *Client) Engines() EngineInterface {
+	// If we have a CRD client, use it
+	if c.crdClient != nil {
+		return &crdEngineClient{crdClient: c.crdClient}
+	}
+	// Otherwise use the HTTP client
+	return &engineClient{client: c}
+}
@@ -9,13 +9,19 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
 // LonghornCRDClient uses Kubernetes API to interact with Longhorn CRDs
 type LonghornCRDClient struct {
 	dynamicClient dynamic.Interface
+	watchClient   dynamic.Interface    // Without request timeout, for long running watches
+	kubeClient    kubernetes.Interface // Core Kubernetes resources, nil if unavailable
 	namespace     string
+
+	conflictRetries int                                      // retries of conflicting updates
+	retryLogger     func(format string, args ...interface{}) // told about retries, may be nil
 }
 
 // Longhorn CRD Group Version Resources
@@ -61,6 +67,36 @@ var (
 		Version:  "v1beta2",
 		Resource: "backuptargets",
 	}
+
+	engineImageGVR = schema.GroupVersionResource{
+		Group:    "longhorn.io",
+		Version:  "v1beta2",
+		Resource: "engineimages",
+	}
+
+	instanceManagerGVR = schema.GroupVersionResource{
+		Group:    "longhorn.io",
+		Version:  "v1beta2",
+		Resource: "instancemanagers",
+	}
+
+	recurringJobGVR = schema.GroupVersionResource{
+		Group:    "longhorn.io",
+		Version:  "v1beta2",
+		Resource: "recurringjobs",
+	}
+
+	orphanGVR = schema.GroupVersionResource{
+		Group:    "longhorn.io",
+		Version:  "v1beta2",
+		Resource: "orphans",
+	}
+
+	shareManagerGVR = schema.GroupVersionResource{
+		Group:    "longhorn.io",
+		Version:  "v1beta2",
+		Resource: "sharemanagers",
+	}
 )
 
 // NewLonghornCRDClient creates a new client that uses Kubernetes CRDs
@@ -74,9 +110,23 @@ func NewLonghornCRDClient(restConfig *rest.Config, namespace string) (*Client, e
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
+	// The request timeout (--timeout) bounds single API calls. Watches, and
+	// the informers of ResourceCache built on them, stay open for as long as
+	// a command runs, so the watch client has no timeout. They end when the
+	// context of the command is cancelled, e.g. on SIGINT or SIGTERM, and
+	// waits such as node drain apply their own deadline.
+	watchConfig := rest.CopyConfig(restConfig)
+	watchConfig.Timeout = 0
+	watchClient, err := dynamic.NewForConfig(watchConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	crdClient := &LonghornCRDClient{
-		dynamicClient: dynamicClient,
-		namespace:     namespace,
+		dynamicClient:   dynamicClient,
+		watchClient:     watchClient,
+		namespace:       namespace,
+		conflictRetries: DefaultConflictRetries,
 	}
 
 	// Return a Client that wraps the CRD client
@@ -98,12 +148,12 @@ type crdNodeClient struct {
 }
 
 // List returns all Longhorn nodes
-func (c *crdNodeClient) List() ([]Node, error) {
+func (c *crdNodeClient) List(ctx context.Context) ([]Node, error) {
 	debugLog("Listing Longhorn nodes via CRD")
 
 	list, err := c.crdClient.dynamicClient.Resource(nodeGVR).
 		Namespace(c.crdClient.namespace).
-		List(context.TODO(), metav1.ListOptions{})
+		List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
@@ -122,12 +172,12 @@ func (c *crdNodeClient) List() ([]Node, error) {
 }
 
 // Get returns a specific node
-func (c *crdNodeClient) Get(name string) (*Node, error) {
+func (c *crdNodeClient) Get(ctx context.Context, name string) (*Node, error) {
 	debugLog("Getting Longhorn node %s via CRD", name)
 
 	unstructuredNode, err := c.crdClient.dynamicClient.Resource(nodeGVR).
 		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), name, metav1.GetOptions{})
+		Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
 	}
@@ -136,46 +186,53 @@ func (c *crdNodeClient) Get(name string) (*Node, error) {
 }
 
 // Update updates a node
-func (c *crdNodeClient) Update(name string, update *NodeUpdate) (*Node, error) {
-	// Get current node CRD
-	currentUnstructured, err := c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current node: %w", err)
-	}
+func (c *crdNodeClient) Update(
+	ctx context.Context,
+	name string,
+	update *NodeUpdate,
+) (*Node, error) {
+	return c.updateSpec(ctx, name, func(spec map[string]interface{}) error {
+		if update.AllowScheduling != nil {
+			spec["allowScheduling"] = *update.AllowScheduling
+		}
+		if update.EvictionRequested != nil {
+			spec["evictionRequested"] = *update.EvictionRequested
+		}
+		if update.Tags != nil {
+			spec["tags"] = stringsToInterfaces(update.Tags)
+		}
+		return nil
+	})
+}
 
-	// Get the spec
-	spec, found, err := unstructured.NestedMap(currentUnstructured.Object, "spec")
-	if err != nil || !found {
-		spec = make(map[string]interface{})
-	}
+// updateSpec applies mutate to the spec of a node and writes it back,
+// retrying when the node was changed concurrently
+func (c *crdNodeClient) updateSpec(
+	ctx context.Context,
+	name string,
+	mutate func(spec map[string]interface{}) error,
+) (*Node, error) {
+	var mutateErr error
+	mutateNode := func(node *unstructured.Unstructured) error {
+		spec, found, err := unstructured.NestedMap(node.Object, "spec")
+		if err != nil || !found {
+			spec = make(map[string]interface{})
+		}
 
-	// Apply updates to spec
-	if update.AllowScheduling != nil {
-		spec["allowScheduling"] = *update.AllowScheduling
-	}
-	if update.EvictionRequested != nil {
-		spec["evictionRequested"] = *update.EvictionRequested
-	}
-	if update.Tags != nil {
-		// Convert tags to []interface{}
-		tags := make([]interface{}, len(update.Tags))
-		for i, tag := range update.Tags {
-			tags[i] = tag
+		if mutateErr = mutate(spec); mutateErr != nil {
+			return mutateErr
 		}
-		spec["tags"] = tags
-	}
 
-	// Set the updated spec back
-	if err := unstructured.SetNestedMap(currentUnstructured.Object, spec, "spec"); err != nil {
-		return nil, fmt.Errorf("failed to update spec: %w", err)
+		if err := unstructured.SetNestedMap(node.Object, spec, "spec"); err != nil {
+			return fmt.Errorf("failed to update spec: %w", err)
+		}
+		return nil
 	}
 
-	// Update via CRD
-	updated, err := c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Update(context.TODO(), currentUnstructured, metav1.UpdateOptions{})
+	updated, err := c.crdClient.updateWithRetry(ctx, nodeGVR, name, mutateNode)
+	if mutateErr != nil {
+		return nil, mutateErr
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update node %s: %w", name, err)
 	}
@@ -183,6 +240,15 @@ func (c *crdNodeClient) Update(name string, update *NodeUpdate) (*Node, error) {
 	return unstructuredToNode(updated)
 }
 
+// stringsToInterfaces converts a string list for an unstructured object
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
 // Helper functions to convert between unstructured and typed objects
 
 func unstructuredToNode(u *unstructured.Unstructured) (*Node, error) {
@@ -316,6 +382,9 @@ func unstructuredToNode(u *unstructured.Unstructured) (*Node, error) {
 						if v, ok := diskStatus["diskUUID"].(string); ok {
 							disk.DiskUUID = v
 						}
+						if conditions, ok := diskStatus["conditions"].([]interface{}); ok {
+							disk.Conditions = unstructuredConditions(conditions)
+						}
 
 						// Get scheduled replicas
 						if replicas, ok := diskStatus["scheduledReplica"].(map[string]interface{}); ok {
@@ -338,6 +407,42 @@ func unstructuredToNode(u *unstructured.Unstructured) (*Node, error) {
 	return node, nil
 }
 
+// unstructuredConditions converts a list of unstructured conditions to a map keyed by type
+func unstructuredConditions(conditions []interface{}) map[string]Status {
+	result := make(map[string]Status)
+	for _, condData := range conditions {
+		condMap, ok := condData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condition := Status{}
+		if v, ok := condMap["type"].(string); ok {
+			condition.Type = v
+		}
+		if v, ok := condMap["status"].(string); ok {
+			condition.Status = v
+		}
+		if v, ok := condMap["message"].(string); ok {
+			condition.Message = v
+		}
+		if v, ok := condMap["reason"].(string); ok {
+			condition.Reason = v
+		}
+		if v, ok := condMap["lastProbeTime"].(string); ok {
+			condition.LastProbeTime = v
+		}
+		if v, ok := condMap["lastTransitionTime"].(string); ok {
+			condition.LastTransitionTime = v
+		}
+
+		if condition.Type != "" {
+			result[condition.Type] = condition
+		}
+	}
+	return result
+}
+
 func nodeToUnstructured(node *Node) (*unstructured.Unstructured, error) {
 	// This is a simplified version - in production you'd need to handle all fields
 	u := &unstructured.Unstructured{}
@@ -370,53 +475,52 @@ func nodeToUnstructured(node *Node) (*unstructured.Unstructured, error) {
 }
 
 // Implement other methods...
-func (c *crdNodeClient) EnableScheduling(name string) error {
-	_, err := c.Update(name, &NodeUpdate{AllowScheduling: &[]bool{true}[0]})
+func (c *crdNodeClient) EnableScheduling(ctx context.Context, name string) error {
+	_, err := c.Update(ctx, name, &NodeUpdate{AllowScheduling: &[]bool{true}[0]})
 	return err
 }
 
-func (c *crdNodeClient) DisableScheduling(name string) error {
-	_, err := c.Update(name, &NodeUpdate{AllowScheduling: &[]bool{false}[0]})
+func (c *crdNodeClient) DisableScheduling(ctx context.Context, name string) error {
+	_, err := c.Update(ctx, name, &NodeUpdate{AllowScheduling: &[]bool{false}[0]})
 	return err
 }
 
-func (c *crdNodeClient) EvictNode(name string) error {
-	_, err := c.Update(name, &NodeUpdate{EvictionRequested: &[]bool{true}[0]})
+func (c *crdNodeClient) EvictNode(ctx context.Context, name string) error {
+	_, err := c.Update(ctx, name, &NodeUpdate{EvictionRequested: &[]bool{true}[0]})
 	return err
 }
 
-func (c *crdNodeClient) AddNodeTag(nodeName, tag string) error {
-	node, err := c.Get(nodeName)
-	if err != nil {
-		return err
-	}
+func (c *crdNodeClient) AddNodeTag(ctx context.Context, nodeName, tag string) error {
+	_, err := c.updateSpec(ctx, nodeName, func(spec map[string]interface{}) error {
+		tags, _, _ := unstructured.NestedStringSlice(spec, "tags")
 
-	// Check if tag already exists
-	for _, t := range node.Tags {
-		if t == tag {
-			return nil
+		// Check if tag already exists
+		for _, t := range tags {
+			if t == tag {
+				return nil
+			}
 		}
-	}
 
-	tags := append(node.Tags, tag)
-	_, err = c.Update(nodeName, &NodeUpdate{Tags: tags})
+		spec["tags"] = stringsToInterfaces(append(tags, tag))
+		return nil
+	})
 	return err
 }
 
-func (c *crdNodeClient) RemoveNodeTag(nodeName, tag string) error {
-	node, err := c.Get(nodeName)
-	if err != nil {
-		return err
-	}
+func (c *crdNodeClient) RemoveNodeTag(ctx context.Context, nodeName, tag string) error {
+	_, err := c.updateSpec(ctx, nodeName, func(spec map[string]interface{}) error {
+		tags, _, _ := unstructured.NestedStringSlice(spec, "tags")
 
-	// Filter out the tag
-	var tags []string
-	for _, t := range node.Tags {
-		if t != tag {
-			tags = append(tags, t)
+		// Filter out the tag
+		remaining := []string{}
+		for _, t := range tags {
+			if t != tag {
+				remaining = append(remaining, t)
+			}
 		}
-	}
 
-	_, err = c.Update(nodeName, &NodeUpdate{Tags: tags})
+		spec["tags"] = stringsToInterfaces(remaining)
+		return nil
+	})
 	return err
 }
@@ -0,0 +1,132 @@
+// pkg/client/engine_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// engineClient implementation for CRDs
+type crdEngineClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns all Longhorn engines
+func (c *crdEngineClient) List(ctx context.Context) ([]Engine, error) {
+	debugLog("Listing Longhorn engines via CRD")
+
+	list, err := c.crdClient.dynamicClient.Resource(engineGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list engines: %w", err)
+	}
+
+	engines := make([]Engine, 0, len(list.Items))
+	for _, item := range list.Items {
+		engines = append(engines, *unstructuredToEngine(&item))
+	}
+
+	return engines, nil
+}
+
+// Get returns a specific engine
+func (c *crdEngineClient) Get(ctx context.Context, name string) (*Engine, error) {
+	debugLog("Getting Longhorn engine %s via CRD", name)
+
+	unstructuredEngine, err := c.crdClient.dynamicClient.Resource(engineGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engine %s: %w", name, err)
+	}
+
+	return unstructuredToEngine(unstructuredEngine), nil
+}
+
+// Helper function to convert unstructured to Engine
+func unstructuredToEngine(u *unstructured.Unstructured) *Engine {
+	engine := &Engine{
+		Name: u.GetName(),
+	}
+
+	if spec, found, err := unstructured.NestedMap(u.Object, "spec"); err == nil && found {
+		if v, ok := spec["volumeName"].(string); ok {
+			engine.VolumeName = v
+		}
+		if v, ok := spec["nodeID"].(string); ok {
+			engine.NodeID = v
+		}
+		if v, ok := spec["image"].(string); ok {
+			engine.Image = v
+		}
+		engine.ReplicaAddressMap = stringMap(spec["replicaAddressMap"])
+	}
+
+	if status, found, err := unstructured.NestedMap(u.Object, "status"); err == nil && found {
+		if v, ok := status["currentState"].(string); ok {
+			engine.CurrentState = v
+		}
+		if v, ok := status["endpoint"].(string); ok {
+			engine.Endpoint = v
+		}
+		if v, ok := status["instanceManagerName"].(string); ok {
+			engine.InstanceManager = v
+		}
+		// Prefer the addresses the engine is actually using
+		if current := stringMap(status["currentReplicaAddressMap"]); len(current) > 0 {
+			engine.ReplicaAddressMap = current
+		}
+		engine.ReplicaModeMap = stringMap(status["replicaModeMap"])
+
+		if rebuilds, ok := status["rebuildStatus"].(map[string]interface{}); ok {
+			engine.RebuildStatus = make(map[string]RebuildStatus)
+			for address, data := range rebuilds {
+				rebuildMap, ok := data.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				rebuild := RebuildStatus{}
+				if v, ok := rebuildMap["isRebuilding"].(bool); ok {
+					rebuild.IsRebuilding = v
+				}
+				if v, ok := rebuildMap["progress"].(int64); ok {
+					rebuild.Progress = int(v)
+				} else if v, ok := rebuildMap["progress"].(float64); ok {
+					rebuild.Progress = int(v)
+				}
+				if v, ok := rebuildMap["state"].(string); ok {
+					rebuild.State = v
+				}
+				if v, ok := rebuildMap["error"].(string); ok {
+					rebuild.Error = v
+				}
+				if v, ok := rebuildMap["fromReplicaAddress"].(string); ok {
+					rebuild.FromReplicaAddress = v
+				}
+				engine.RebuildStatus[address] = rebuild
+			}
+		}
+	}
+
+	return engine
+}
+
+// stringMap converts an unstructured map of strings to map[string]string
+func stringMap(data interface{}) map[string]string {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			result[k] = s
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+// pkg/client/engineimage_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// engineImageClient implementation for CRDs
+type crdEngineImageClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns all engine images
+func (c *crdEngineImageClient) List(ctx context.Context) ([]EngineImage, error) {
+	debugLog("Listing Longhorn engine images via CRD")
+
+	list, err := c.crdClient.dynamicClient.Resource(engineImageGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list engine images: %w", err)
+	}
+
+	images := make([]EngineImage, 0, len(list.Items))
+	for _, item := range list.Items {
+		images = append(images, *unstructuredToEngineImage(&item))
+	}
+
+	return images, nil
+}
+
+// Get returns a specific engine image
+func (c *crdEngineImageClient) Get(ctx context.Context, name string) (*EngineImage, error) {
+	debugLog("Getting Longhorn engine image %s via CRD", name)
+
+	unstructuredImage, err := c.crdClient.dynamicClient.Resource(engineImageGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engine image %s: %w", name, err)
+	}
+
+	return unstructuredToEngineImage(unstructuredImage), nil
+}
+
+// Delete deletes an engine image
+func (c *crdEngineImageClient) Delete(ctx context.Context, name string) error {
+	debugLog("Deleting Longhorn engine image %s via CRD", name)
+
+	err := c.crdClient.dynamicClient.Resource(engineImageGVR).
+		Namespace(c.crdClient.namespace).
+		Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete engine image %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Helper function to convert unstructured to EngineImage
+func unstructuredToEngineImage(u *unstructured.Unstructured) *EngineImage {
+	image := &EngineImage{
+		Name:    u.GetName(),
+		Created: u.GetCreationTimestamp().Format(time.RFC3339),
+	}
+
+	if v, found, _ := unstructured.NestedString(u.Object, "spec", "image"); found {
+		image.Image = v
+	}
+
+	if status, found, err := unstructured.NestedMap(u.Object, "status"); err == nil && found {
+		if v, ok := status["state"].(string); ok {
+			image.State = v
+		}
+		if v, ok := status["refCount"].(int64); ok {
+			image.RefCount = int(v)
+		} else if v, ok := status["refCount"].(float64); ok {
+			image.RefCount = int(v)
+		}
+		if deployments, ok := status["nodeDeploymentMap"].(map[string]interface{}); ok {
+			image.NodeDeploymentMap = make(map[string]bool, len(deployments))
+			for node, deployed := range deployments {
+				if v, ok := deployed.(bool); ok {
+					image.NodeDeploymentMap[node] = v
+				}
+			}
+		}
+		if conditions, ok := status["conditions"].([]interface{}); ok {
+			image.Conditions = unstructuredConditions(conditions)
+		}
+	}
+
+	return image
+}
@@ -0,0 +1,115 @@
+// pkg/client/errors.go
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Kinds of API errors. Errors of both the Longhorn manager API and the
+// Kubernetes API are classified by KindOf; use errors.Is for errors created
+// by this package.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrConflict      = errors.New("conflict")
+	ErrForbidden     = errors.New("forbidden")
+	ErrUnavailable   = errors.New("unavailable")
+)
+
+// kindError attaches an error kind to an error without changing its message
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// withKind marks err as an error of the given kind
+func withKind(kind, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+// KindOf returns the kind of an API error: ErrNotFound, ErrAlreadyExists,
+// ErrConflict, ErrForbidden or ErrUnavailable. It returns nil for errors of
+// any other kind.
+func KindOf(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, kind := range []error{
+		ErrNotFound, ErrAlreadyExists, ErrConflict, ErrForbidden, ErrUnavailable,
+	} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return ErrNotFound
+	case apierrors.IsAlreadyExists(err):
+		return ErrAlreadyExists
+	case apierrors.IsConflict(err):
+		return ErrConflict
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return ErrForbidden
+	case apierrors.IsServiceUnavailable(err), apierrors.IsTimeout(err),
+		apierrors.IsServerTimeout(err), apierrors.IsTooManyRequests(err):
+		return ErrUnavailable
+	}
+
+	return nil
+}
+
+// Is reports the kind of a Longhorn manager API error, so errors.Is works
+// with the error kinds of this package
+func (e *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound || e.Code == "NotFound"
+	case ErrAlreadyExists:
+		return e.Code == "AlreadyExists" || e.Code == "NotUnique"
+	case ErrConflict:
+		return e.Status == http.StatusConflict && !e.Is(ErrAlreadyExists)
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden || e.Status == http.StatusUnauthorized
+	case ErrUnavailable:
+		return e.Status == http.StatusBadGateway ||
+			e.Status == http.StatusServiceUnavailable ||
+			e.Status == http.StatusGatewayTimeout
+	}
+	return false
+}
+
+// DiskInUseError is returned when removing a disk that still has replicas
+// scheduled on it
+type DiskInUseError struct {
+	Node     string
+	Disk     string
+	Replicas int
+}
+
+func (e *DiskInUseError) Error() string {
+	return fmt.Sprintf("disk %s on node %s still has %d replica(s) scheduled",
+		e.Disk, e.Node, e.Replicas)
+}
+
+// checkDiskUnused returns a DiskInUseError if replicas are scheduled on a
+// disk of node
+func checkDiskUnused(node *Node, diskID string) error {
+	if disk, ok := node.Disks[diskID]; ok && len(disk.ScheduledReplica) > 0 {
+		return &DiskInUseError{Node: node.Name, Disk: diskID, Replicas: len(disk.ScheduledReplica)}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// pkg/client/errors_test.go
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestKindOf(t *testing.T) {
+	volumes := schema.GroupResource{Group: "longhorn.io", Resource: "volumes"}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "nil", err: nil, want: nil},
+		{name: "plain error", err: errors.New("boom"), want: nil},
+
+		{name: "kube not found", err: apierrors.NewNotFound(volumes, "vol-1"), want: ErrNotFound},
+		{
+			name: "kube already exists",
+			err:  apierrors.NewAlreadyExists(volumes, "vol-1"),
+			want: ErrAlreadyExists,
+		},
+		{
+			name: "kube conflict",
+			err:  apierrors.NewConflict(volumes, "vol-1", errors.New("modified")),
+			want: ErrConflict,
+		},
+		{
+			name: "kube forbidden",
+			err:  apierrors.NewForbidden(volumes, "vol-1", errors.New("denied")),
+			want: ErrForbidden,
+		},
+		{name: "kube unauthorized", err: apierrors.NewUnauthorized("expired"), want: ErrForbidden},
+		{
+			name: "kube unavailable",
+			err:  apierrors.NewServiceUnavailable("restarting"),
+			want: ErrUnavailable,
+		},
+		{name: "kube timeout", err: apierrors.NewTimeoutError("slow", 1), want: ErrUnavailable},
+		{name: "kube throttled", err: apierrors.NewTooManyRequests("slow down", 1), want: ErrUnavailable},
+		{name: "kube bad request", err: apierrors.NewBadRequest("invalid"), want: nil},
+
+		{name: "API 404", err: &ErrorResponse{Status: http.StatusNotFound}, want: ErrNotFound},
+		{name: "API NotFound code", err: &ErrorResponse{Code: "NotFound"}, want: ErrNotFound},
+		{
+			name: "API already exists",
+			err:  &ErrorResponse{Status: http.StatusConflict, Code: "AlreadyExists"},
+			want: ErrAlreadyExists,
+		},
+		{
+			name: "API not unique",
+			err:  &ErrorResponse{Status: http.StatusUnprocessableEntity, Code: "NotUnique"},
+			want: ErrAlreadyExists,
+		},
+		{name: "API 409", err: &ErrorResponse{Status: http.StatusConflict}, want: ErrConflict},
+		{name: "API 401", err: &ErrorResponse{Status: http.StatusUnauthorized}, want: ErrForbidden},
+		{name: "API 403", err: &ErrorResponse{Status: http.StatusForbidden}, want: ErrForbidden},
+		{name: "API 502", err: &ErrorResponse{Status: http.StatusBadGateway}, want: ErrUnavailable},
+		{
+			name: "API 503",
+			err:  &ErrorResponse{Status: http.StatusServiceUnavailable},
+			want: ErrUnavailable,
+		},
+		{name: "API 504", err: &ErrorResponse{Status: http.StatusGatewayTimeout}, want: ErrUnavailable},
+		{name: "API 500", err: &ErrorResponse{Status: http.StatusInternalServerError}, want: nil},
+
+		{
+			name: "wrapped kube error",
+			err:  fmt.Errorf("failed to get volume: %w", apierrors.NewNotFound(volumes, "vol-1")),
+			want: ErrNotFound,
+		},
+		{
+			name: "wrapped API error",
+			err:  fmt.Errorf("failed to create volume: %w", &ErrorResponse{Status: http.StatusForbidden}),
+			want: ErrForbidden,
+		},
+		{
+			name: "wrapped sentinel",
+			err:  fmt.Errorf("volume vol-1 %w", ErrNotFound),
+			want: ErrNotFound,
+		},
+		{
+			name: "transport error",
+			err:  withKind(ErrUnavailable, errors.New("connection refused")),
+			want: ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.want {
+				t.Errorf("KindOf() = %v, want %v", got, tt.want)
+			}
+			if tt.want != nil && !errors.Is(tt.err, tt.want) && !isKubeError(tt.err) {
+				t.Errorf("errors.Is(err, %v) = false", tt.want)
+			}
+		})
+	}
+}
+
+// isKubeError reports whether err is a Kubernetes API error, which is only
+// classified by KindOf
+func isKubeError(err error) bool {
+	var status apierrors.APIStatus
+	return errors.As(err, &status)
+}
+
+func TestWithKindKeepsMessage(t *testing.T) {
+	inner := errors.New("dial tcp: connection refused")
+	err := withKind(ErrUnavailable, inner)
+
+	if err.Error() != inner.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), inner.Error())
+	}
+	if !errors.Is(err, inner) {
+		t.Error("errors.Is(err, inner) = false")
+	}
+}
@@ -0,0 +1,293 @@
+// pkg/client/event_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Kubernetes core events
+var eventGVR = schema.GroupVersionResource{
+	Group:    "",
+	Version:  "v1",
+	Resource: "events",
+}
+
+// eventWatchBackoff delays reopening an event watch that failed or ended
+// right away, so that an unhealthy API server is not flooded with requests
+var eventWatchBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    10,
+	Cap:      30 * time.Second,
+}
+
+// minEventWatchDuration is how long a watch must last to count as healthy
+const minEventWatchDuration = 10 * time.Second
+
+// eventClient implementation using Kubernetes events
+type crdEventClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns the events of Longhorn resources, oldest first
+func (c *crdEventClient) List(ctx context.Context, opts EventListOptions) ([]Event, error) {
+	debugLog("Listing Longhorn events via Kubernetes API")
+
+	list, err := c.crdClient.dynamicClient.Resource(eventGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{FieldSelector: eventFieldSelector(opts)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	events := make([]Event, 0, len(list.Items))
+	for _, item := range list.Items {
+		event, ok := c.convert(&item, opts)
+		if ok {
+			events = append(events, *event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastTimestamp.Before(events[j].LastTimestamp)
+	})
+	return events, nil
+}
+
+// Watch calls callback for every matching event, starting with the existing
+// ones, and then for every new occurrence until ctx is cancelled. An event
+// that is only re-sent without its count increasing is reported once. A
+// watch that fails or ends right away is reopened with increasing delays.
+func (c *crdEventClient) Watch(
+	ctx context.Context,
+	opts EventListOptions,
+	callback func(Event),
+) error {
+	debugLog("Watching Longhorn events via Kubernetes API")
+
+	resource := c.crdClient.watchClient.Resource(eventGVR).Namespace(c.crdClient.namespace)
+	selector := eventFieldSelector(opts)
+	seen := make(map[string]int)
+
+	report := func(u *unstructured.Unstructured) {
+		event, ok := c.convert(u, opts)
+		if !ok {
+			return
+		}
+		uid := string(u.GetUID())
+		if count, exists := seen[uid]; exists && event.Count <= count {
+			return
+		}
+		seen[uid] = event.Count
+		callback(*event)
+	}
+	forget := func(u *unstructured.Unstructured) {
+		delete(seen, string(u.GetUID()))
+	}
+
+	backoff := eventWatchBackoff
+	resourceVersion := ""
+	for {
+		if resourceVersion == "" {
+			list, err := resource.List(ctx, metav1.ListOptions{FieldSelector: selector})
+			if err != nil {
+				return fmt.Errorf("failed to list events: %w", err)
+			}
+			sort.SliceStable(list.Items, func(i, j int) bool {
+				return eventTime(&list.Items[i]) < eventTime(&list.Items[j])
+			})
+
+			// Events that expired while the watch was down are forgotten
+			listed := make(map[string]bool, len(list.Items))
+			for i := range list.Items {
+				listed[string(list.Items[i].GetUID())] = true
+				report(&list.Items[i])
+			}
+			for uid := range seen {
+				if !listed[uid] {
+					delete(seen, uid)
+				}
+			}
+			resourceVersion = list.GetResourceVersion()
+		}
+
+		watcher, err := resource.Watch(ctx, metav1.ListOptions{
+			FieldSelector:   selector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				resourceVersion = ""
+				continue
+			}
+			return fmt.Errorf("failed to watch events: %w", err)
+		}
+
+		started := time.Now()
+		resourceVersion, err = c.consume(ctx, watcher, resourceVersion, report, forget)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil && time.Since(started) >= minEventWatchDuration {
+			backoff = eventWatchBackoff
+			debugLog("Event watch closed, resuming from resource version %q", resourceVersion)
+			continue
+		}
+
+		delay := backoff.Step()
+		debugLog("Event watch ended early (%v), resuming from resource version %q in %s",
+			err, resourceVersion, delay.Round(time.Millisecond))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// consume reads a watch until it ends and returns the resource version to
+// resume from. An empty version means the events must be listed again. Any
+// other error the watch reports is returned.
+func (c *crdEventClient) consume(
+	ctx context.Context,
+	watcher watch.Interface,
+	resourceVersion string,
+	report func(*unstructured.Unstructured),
+	forget func(*unstructured.Unstructured),
+) (string, error) {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case change, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+			switch change.Type {
+			case watch.Added, watch.Modified:
+				if u, ok := change.Object.(*unstructured.Unstructured); ok {
+					report(u)
+					resourceVersion = u.GetResourceVersion()
+				}
+			case watch.Deleted:
+				if u, ok := change.Object.(*unstructured.Unstructured); ok {
+					forget(u)
+					resourceVersion = u.GetResourceVersion()
+				}
+			case watch.Error:
+				status := apierrors.FromObject(change.Object)
+				debugLog("Event watch error: %v", status)
+				if apierrors.IsResourceExpired(status) || apierrors.IsGone(status) {
+					return "", nil
+				}
+				return resourceVersion, status
+			}
+		}
+	}
+}
+
+// convert turns a Kubernetes event into an Event if it concerns a Longhorn
+// resource and matches the options
+func (c *crdEventClient) convert(
+	u *unstructured.Unstructured,
+	opts EventListOptions,
+) (*Event, bool) {
+	var e corev1.Event
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &e); err != nil {
+		debugLog("Failed to convert event %s: %v", u.GetName(), err)
+		return nil, false
+	}
+
+	if !strings.HasPrefix(e.InvolvedObject.APIVersion, "longhorn.io/") {
+		return nil, false
+	}
+	if opts.ResourceType != "" && !matchesKind(e.InvolvedObject.Kind, opts.ResourceType) {
+		return nil, false
+	}
+
+	event := &Event{
+		Type:           e.Type,
+		Object:         strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name,
+		Reason:         e.Reason,
+		Message:        e.Message,
+		FirstTimestamp: e.FirstTimestamp.Time,
+		LastTimestamp:  e.LastTimestamp.Time,
+		Count:          int(e.Count),
+	}
+
+	// Events recorded through the events.k8s.io API use eventTime and series
+	if event.FirstTimestamp.IsZero() {
+		event.FirstTimestamp = e.EventTime.Time
+	}
+	if e.Series != nil {
+		event.LastTimestamp = e.Series.LastObservedTime.Time
+		if event.Count == 0 {
+			event.Count = int(e.Series.Count)
+		}
+	}
+	if event.LastTimestamp.IsZero() {
+		event.LastTimestamp = event.FirstTimestamp
+	}
+	if event.Count == 0 {
+		event.Count = 1
+	}
+
+	if !opts.Since.IsZero() && event.LastTimestamp.Before(opts.Since) {
+		return nil, false
+	}
+	return event, true
+}
+
+// eventFieldSelector filters events on the server where possible
+func eventFieldSelector(opts EventListOptions) string {
+	set := fields.Set{}
+	if opts.ResourceName != "" {
+		set["involvedObject.name"] = opts.ResourceName
+	}
+	if opts.EventType != "" {
+		set["type"] = opts.EventType
+	}
+	if len(set) == 0 {
+		return ""
+	}
+	return fields.SelectorFromSet(set).String()
+}
+
+// matchesKind compares a resource kind with a user supplied resource type such
+// as "volume", "volumes" or "InstanceManager"
+func matchesKind(kind, resourceType string) bool {
+	resourceType = strings.ReplaceAll(strings.ToLower(resourceType), "-", "")
+	kind = strings.ToLower(kind)
+	return kind == resourceType || kind+"s" == resourceType
+}
+
+// eventTime returns a sortable timestamp of an unstructured event
+func eventTime(u *unstructured.Unstructured) string {
+	if t, found, _ := unstructured.NestedString(u.Object, "lastTimestamp"); found && t != "" {
+		return t
+	}
+	t, _, _ := unstructured.NestedString(u.Object, "eventTime")
+	return t
+}
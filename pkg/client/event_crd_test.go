@@ -0,0 +1,108 @@
+// pkg/client/event_crd_test.go
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func watchedEvent(uid, resourceVersion string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetUID(types.UID(uid))
+	u.SetResourceVersion(resourceVersion)
+	return u
+}
+
+func TestEventConsume(t *testing.T) {
+	expired := &metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusGone,
+		Reason: metav1.StatusReasonExpired,
+	}
+	internal := &metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusInternalServerError,
+		Reason: metav1.StatusReasonInternalError,
+	}
+
+	tests := []struct {
+		name        string
+		changes     []watch.Event
+		wantVersion string
+		wantErr     bool
+		wantSeen    []string
+	}{
+		{
+			name: "closed watch resumes from last version",
+			changes: []watch.Event{
+				{Type: watch.Added, Object: watchedEvent("a", "11")},
+				{Type: watch.Modified, Object: watchedEvent("b", "12")},
+			},
+			wantVersion: "12",
+			wantSeen:    []string{"a", "b"},
+		},
+		{
+			name: "deleted events are forgotten",
+			changes: []watch.Event{
+				{Type: watch.Added, Object: watchedEvent("a", "11")},
+				{Type: watch.Deleted, Object: watchedEvent("a", "13")},
+			},
+			wantVersion: "13",
+		},
+		{
+			name: "expired version lists again",
+			changes: []watch.Event{
+				{Type: watch.Added, Object: watchedEvent("a", "11")},
+				{Type: watch.Error, Object: expired},
+			},
+			wantSeen: []string{"a"},
+		},
+		{
+			name: "other errors are returned",
+			changes: []watch.Event{
+				{Type: watch.Error, Object: internal},
+			},
+			wantVersion: "10",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher := watch.NewFakeWithChanSize(len(tt.changes), false)
+			for _, change := range tt.changes {
+				watcher.Action(change.Type, change.Object)
+			}
+			if tt.changes[len(tt.changes)-1].Type != watch.Error {
+				watcher.Stop()
+			}
+
+			seen := make(map[string]bool)
+			report := func(u *unstructured.Unstructured) { seen[string(u.GetUID())] = true }
+			forget := func(u *unstructured.Unstructured) { delete(seen, string(u.GetUID())) }
+
+			c := &crdEventClient{}
+			version, err := c.consume(context.Background(), watcher, "10", report, forget)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("consume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.wantVersion {
+				t.Errorf("consume() version = %q, want %q", version, tt.wantVersion)
+			}
+			if len(seen) != len(tt.wantSeen) {
+				t.Errorf("seen = %v, want %v", seen, tt.wantSeen)
+			}
+			for _, uid := range tt.wantSeen {
+				if !seen[uid] {
+					t.Errorf("seen = %v, want %v", seen, tt.wantSeen)
+				}
+			}
+		})
+	}
+}
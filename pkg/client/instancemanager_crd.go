@@ -0,0 +1,135 @@
+// pkg/client/instancemanager_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// instanceManagerClient implementation for CRDs
+type crdInstanceManagerClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns all instance managers
+func (c *crdInstanceManagerClient) List(ctx context.Context) ([]InstanceManager, error) {
+	debugLog("Listing Longhorn instance managers via CRD")
+
+	list, err := c.crdClient.dynamicClient.Resource(instanceManagerGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instance managers: %w", err)
+	}
+
+	managers := make([]InstanceManager, 0, len(list.Items))
+	for _, item := range list.Items {
+		managers = append(managers, *unstructuredToInstanceManager(&item))
+	}
+
+	return managers, nil
+}
+
+// Get returns a specific instance manager
+func (c *crdInstanceManagerClient) Get(ctx context.Context, name string) (*InstanceManager, error) {
+	debugLog("Getting Longhorn instance manager %s via CRD", name)
+
+	unstructuredManager, err := c.crdClient.dynamicClient.Resource(instanceManagerGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance manager %s: %w", name, err)
+	}
+
+	return unstructuredToInstanceManager(unstructuredManager), nil
+}
+
+// Helper function to convert unstructured to InstanceManager
+func unstructuredToInstanceManager(u *unstructured.Unstructured) *InstanceManager {
+	manager := &InstanceManager{
+		Name: u.GetName(),
+	}
+
+	if spec, found, err := unstructured.NestedMap(u.Object, "spec"); err == nil && found {
+		if v, ok := spec["nodeID"].(string); ok {
+			manager.NodeID = v
+		}
+		if v, ok := spec["type"].(string); ok {
+			manager.Type = v
+		}
+		if v, ok := spec["dataEngine"].(string); ok {
+			manager.DataEngine = v
+		}
+		if v, ok := spec["image"].(string); ok {
+			manager.Image = v
+		}
+	}
+
+	if status, found, err := unstructured.NestedMap(u.Object, "status"); err == nil && found {
+		if v, ok := status["currentState"].(string); ok {
+			manager.CurrentState = v
+		}
+		if v, ok := status["ip"].(string); ok {
+			manager.IP = v
+		}
+
+		// Newer Longhorn versions split the instances by type, older ones
+		// report all of them under "instances"
+		engines := unstructuredInstances(status["instanceEngines"], "engine")
+		replicas := unstructuredInstances(status["instanceReplicas"], "replica")
+		manager.Instances = append(engines, replicas...)
+		if len(manager.Instances) == 0 {
+			manager.Instances = unstructuredInstances(status["instances"], "")
+		}
+		sort.Slice(manager.Instances, func(i, j int) bool {
+			return manager.Instances[i].Name < manager.Instances[j].Name
+		})
+	}
+
+	if manager.DataEngine == "" {
+		manager.DataEngine = "v1"
+	}
+
+	return manager
+}
+
+// unstructuredInstances converts a map of instance processes
+func unstructuredInstances(data interface{}, instanceType string) []Instance {
+	processes, ok := data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	instances := make([]Instance, 0, len(processes))
+	for name, processData := range processes {
+		process, ok := processData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		instance := Instance{Name: name, Type: instanceType}
+		if status, ok := process["status"].(map[string]interface{}); ok {
+			if v, ok := status["state"].(string); ok {
+				instance.State = v
+			}
+			if v, ok := status["errorMsg"].(string); ok {
+				instance.ErrorMsg = v
+			}
+			if v, ok := status["portStart"].(int64); ok {
+				instance.Port = int(v)
+			} else if v, ok := status["portStart"].(float64); ok {
+				instance.Port = int(v)
+			}
+			if v, ok := status["type"].(string); ok && instance.Type == "" {
+				instance.Type = v
+			}
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances
+}
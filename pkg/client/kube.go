@@ -3,7 +3,9 @@ package client
 
 import (
 	"fmt"
+	"net/http"
 	"path/filepath"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -16,6 +18,8 @@ type KubeConfig struct {
 	ConfigPath string
 	Context    string
 	Namespace  string
+	Timeout    time.Duration // Timeout of each request, 0 for none
+	Retry      *RetryPolicy  // nil uses DefaultRetryPolicy
 }
 
 // NewKubeClient creates a new Kubernetes client from kubeconfig
@@ -42,6 +46,13 @@ func NewKubeClient(config *KubeConfig) (*kubernetes.Clientset, *rest.Config, err
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create kubernetes config: %w", err)
 	}
+	if config.Timeout > 0 {
+		restConfig.Timeout = config.Timeout
+	}
+	policy := retryPolicyOrDefault(config.Retry)
+	restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return newKubeRetryTransport(rt, policy)
+	})
 
 	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
@@ -53,7 +64,7 @@ func NewKubeClient(config *KubeConfig) (*kubernetes.Clientset, *rest.Config, err
 
 // NewClientFromKubeconfig creates a Longhorn client using kubeconfig
 func NewClientFromKubeconfig(kubeConfig *KubeConfig) (*Client, error) {
-	_, restConfig, err := NewKubeClient(kubeConfig)
+	clientset, restConfig, err := NewKubeClient(kubeConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +76,14 @@ func NewClientFromKubeconfig(kubeConfig *KubeConfig) (*Client, error) {
 
 	// Use CRD client for Kubernetes environments
 	debugLog("Using CRD client for Longhorn resources")
-	return NewLonghornCRDClient(restConfig, namespace)
+	c, err := NewLonghornCRDClient(restConfig, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the clientset for core resources such as PVs and PVCs
+	c.crdClient.kubeClient = clientset
+	return c, nil
 }
 
 // GetCurrentNamespace gets the current namespace from kubeconfig context
@@ -2,9 +2,9 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 )
 
@@ -14,17 +14,17 @@ type nodeClient struct {
 }
 
 // List returns all nodes
-func (c *nodeClient) List() ([]Node, error) {
+func (c *nodeClient) List(ctx context.Context) ([]Node, error) {
 	debugLog("Listing nodes")
 
-	resp, err := c.client.doRequest("GET", "/nodes", nil)
+	resp, err := c.client.doRequest(ctx, "GET", "/nodes", nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, errorFromResponse(resp)
 	}
 
 	var result struct {
@@ -38,21 +38,21 @@ func (c *nodeClient) List() ([]Node, error) {
 }
 
 // Get returns a specific node
-func (c *nodeClient) Get(name string) (*Node, error) {
+func (c *nodeClient) Get(ctx context.Context, name string) (*Node, error) {
 	debugLog("Getting node: %s", name)
 
-	resp, err := c.client.doRequest("GET", fmt.Sprintf("/nodes/%s", name), nil)
+	resp, err := c.client.doRequest(ctx, "GET", fmt.Sprintf("/nodes/%s", name), nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("node %s not found", name)
+		return nil, fmt.Errorf("node %s %w", name, ErrNotFound)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, errorFromResponse(resp)
 	}
 
 	var node Node
@@ -64,18 +64,17 @@ func (c *nodeClient) Get(name string) (*Node, error) {
 }
 
 // Update updates a node
-func (c *nodeClient) Update(name string, update *NodeUpdate) (*Node, error) {
+func (c *nodeClient) Update(ctx context.Context, name string, update *NodeUpdate) (*Node, error) {
 	debugLog("Updating node: %s", name)
 
-	resp, err := c.client.doRequest("PUT", fmt.Sprintf("/nodes/%s", name), update)
+	resp, err := c.client.doRequest(ctx, "PUT", fmt.Sprintf("/nodes/%s", name), update)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update node: %s", string(body))
+		return nil, fmt.Errorf("failed to update node: %w", errorFromResponse(resp))
 	}
 
 	var node Node
@@ -87,38 +86,38 @@ func (c *nodeClient) Update(name string, update *NodeUpdate) (*Node, error) {
 }
 
 // EnableScheduling enables scheduling on a node
-func (c *nodeClient) EnableScheduling(name string) error {
+func (c *nodeClient) EnableScheduling(ctx context.Context, name string) error {
 	update := &NodeUpdate{
 		AllowScheduling: &[]bool{true}[0],
 	}
-	_, err := c.Update(name, update)
+	_, err := c.Update(ctx, name, update)
 	return err
 }
 
 // DisableScheduling disables scheduling on a node
-func (c *nodeClient) DisableScheduling(name string) error {
+func (c *nodeClient) DisableScheduling(ctx context.Context, name string) error {
 	update := &NodeUpdate{
 		AllowScheduling: &[]bool{false}[0],
 	}
-	_, err := c.Update(name, update)
+	_, err := c.Update(ctx, name, update)
 	return err
 }
 
 // EvictNode requests eviction of all replicas from a node
-func (c *nodeClient) EvictNode(name string) error {
+func (c *nodeClient) EvictNode(ctx context.Context, name string) error {
 	update := &NodeUpdate{
 		EvictionRequested: &[]bool{true}[0],
 	}
-	_, err := c.Update(name, update)
+	_, err := c.Update(ctx, name, update)
 	return err
 }
 
 // AddDisk adds a disk to a node
-func (c *nodeClient) AddDisk(nodeName string, disk DiskUpdate) error {
+func (c *nodeClient) AddDisk(ctx context.Context, nodeName string, disk DiskUpdate) error {
 	debugLog("Adding disk to node %s: %s", nodeName, disk.Path)
 
 	// Get current node
-	node, err := c.Get(nodeName)
+	node, err := c.Get(ctx, nodeName)
 	if err != nil {
 		return fmt.Errorf("failed to get node: %w", err)
 	}
@@ -141,41 +140,58 @@ func (c *nodeClient) AddDisk(nodeName string, disk DiskUpdate) error {
 
 	// Add disk via API
 	path := fmt.Sprintf("/nodes/%s/disks", nodeName)
-	resp, err := c.client.doRequest("POST", path, diskData)
+	resp, err := c.client.doRequest(ctx, "POST", path, diskData)
 	if err != nil {
 		return fmt.Errorf("failed to add disk: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to add disk: %s", string(body))
+		return fmt.Errorf("failed to add disk: %w", errorFromResponse(resp))
 	}
 
 	return nil
 }
 
-// RemoveDisk removes a disk from a node
-func (c *nodeClient) RemoveDisk(nodeName, diskID string) error {
+// RemoveDisk removes a disk from a node. Unless forced, a disk with
+// scheduled replicas is not removed and a DiskInUseError is returned.
+func (c *nodeClient) RemoveDisk(
+	ctx context.Context,
+	nodeName, diskID string,
+	opts DiskRemoveOptions,
+) error {
 	debugLog("Removing disk %s from node %s", diskID, nodeName)
 
+	if !opts.Force {
+		node, err := c.Get(ctx, nodeName)
+		if err != nil {
+			return err
+		}
+		if err := checkDiskUnused(node, diskID); err != nil {
+			return err
+		}
+	}
+
 	path := fmt.Sprintf("/nodes/%s/disks/%s", nodeName, diskID)
-	resp, err := c.client.doRequest("DELETE", path, nil)
+	resp, err := c.client.doRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return fmt.Errorf("failed to remove disk: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to remove disk: %s", string(body))
+		return fmt.Errorf("failed to remove disk: %w", errorFromResponse(resp))
 	}
 
 	return nil
 }
 
 // UpdateDiskTags updates tags for a disk
-func (c *nodeClient) UpdateDiskTags(nodeName, diskID string, tags []string) error {
+func (c *nodeClient) UpdateDiskTags(
+	ctx context.Context,
+	nodeName, diskID string,
+	tags []string,
+) error {
 	debugLog("Updating disk tags for %s on node %s", diskID, nodeName)
 
 	updatePayload := map[string]interface{}{
@@ -183,71 +199,100 @@ func (c *nodeClient) UpdateDiskTags(nodeName, diskID string, tags []string) erro
 	}
 
 	path := fmt.Sprintf("/nodes/%s/disks/%s", nodeName, diskID)
-	resp, err := c.client.doRequest("PATCH", path, updatePayload)
+	resp, err := c.client.doRequest(ctx, "PATCH", path, updatePayload)
 	if err != nil {
 		return fmt.Errorf("failed to update disk tags: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update disk tags: %s", string(body))
+		return fmt.Errorf("failed to update disk tags: %w", errorFromResponse(resp))
 	}
 
 	return nil
 }
 
 // EnableDiskScheduling enables scheduling for a specific disk
-func (c *nodeClient) EnableDiskScheduling(nodeName, diskID string) error {
-	return c.updateDiskScheduling(nodeName, diskID, true)
+func (c *nodeClient) EnableDiskScheduling(ctx context.Context, nodeName, diskID string) error {
+	return c.updateDiskScheduling(ctx, nodeName, diskID, true)
 }
 
 // DisableDiskScheduling disables scheduling for a specific disk
-func (c *nodeClient) DisableDiskScheduling(nodeName, diskID string) error {
-	return c.updateDiskScheduling(nodeName, diskID, false)
+func (c *nodeClient) DisableDiskScheduling(ctx context.Context, nodeName, diskID string) error {
+	return c.updateDiskScheduling(ctx, nodeName, diskID, false)
+}
+
+// EvictDisk requests eviction of all replicas from a specific disk
+func (c *nodeClient) EvictDisk(ctx context.Context, nodeName, diskID string) error {
+	debugLog("Requesting eviction for disk %s on node %s", diskID, nodeName)
+
+	return c.patchDisk(ctx, nodeName, diskID, map[string]interface{}{
+		"evictionRequested": true,
+	}, func(d *Disk) {
+		d.EvictionRequested = true
+	})
 }
 
 // updateDiskScheduling is a helper that updates disk scheduling
-func (c *nodeClient) updateDiskScheduling(nodeName, diskID string, allowScheduling bool) error {
+func (c *nodeClient) updateDiskScheduling(
+	ctx context.Context,
+	nodeName, diskID string,
+	allowScheduling bool,
+) error {
 	debugLog("Updating disk scheduling for %s on node %s to %v", diskID, nodeName, allowScheduling)
 
-	updatePayload := map[string]interface{}{
+	err := c.patchDisk(ctx, nodeName, diskID, map[string]interface{}{
 		"allowScheduling": allowScheduling,
+	}, func(d *Disk) {
+		d.AllowScheduling = allowScheduling
+	})
+	if err != nil {
+		return err
 	}
 
+	action := "enabled"
+	if !allowScheduling {
+		action = "disabled"
+	}
+	debugLog("Successfully %s scheduling for disk %s on node %s", action, diskID, nodeName)
+	return nil
+}
+
+// patchDisk sends a partial update for a single disk. If the API doesn't
+// support PATCH on individual disks, apply is used to update the entire node.
+func (c *nodeClient) patchDisk(
+	ctx context.Context,
+	nodeName, diskID string,
+	updatePayload map[string]interface{},
+	apply func(*Disk),
+) error {
 	// Try the direct disk update endpoint first
 	path := fmt.Sprintf("/nodes/%s/disks/%s", nodeName, diskID)
-	resp, err := c.client.doRequest("PATCH", path, updatePayload)
+	resp, err := c.client.doRequest(ctx, "PATCH", path, updatePayload)
 	if err != nil {
 		return fmt.Errorf("failed to update disk: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// If the API doesn't support PATCH on individual disks, fall back to updating the entire node
 	if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotFound {
-		return c.updateDiskSchedulingViaNode(nodeName, diskID, allowScheduling)
+		return c.updateDiskViaNode(ctx, nodeName, diskID, apply)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update disk scheduling: %s", string(body))
+		return fmt.Errorf("failed to update disk: %w", errorFromResponse(resp))
 	}
 
-	action := "enabled"
-	if !allowScheduling {
-		action = "disabled"
-	}
-	debugLog("Successfully %s scheduling for disk %s on node %s", action, diskID, nodeName)
 	return nil
 }
 
-// updateDiskSchedulingViaNode updates disk scheduling by updating the entire node
-func (c *nodeClient) updateDiskSchedulingViaNode(
+// updateDiskViaNode updates a disk by updating the entire node
+func (c *nodeClient) updateDiskViaNode(
+	ctx context.Context,
 	nodeName, diskID string,
-	allowScheduling bool,
+	apply func(*Disk),
 ) error {
 	// Get the current node
-	node, err := c.Get(nodeName)
+	node, err := c.Get(ctx, nodeName)
 	if err != nil {
 		return fmt.Errorf("failed to get node: %w", err)
 	}
@@ -255,21 +300,21 @@ func (c *nodeClient) updateDiskSchedulingViaNode(
 	// Find and update the disk
 	disk, exists := node.Disks[diskID]
 	if !exists {
-		return fmt.Errorf("disk %s not found on node %s", diskID, nodeName)
+		return fmt.Errorf("disk %s %w on node %s", diskID, ErrNotFound, nodeName)
 	}
 
-	// Update the disk's scheduling status
-	disk.AllowScheduling = allowScheduling
+	apply(&disk)
 	node.Disks[diskID] = disk
 
 	// Create the update payload with all disks
 	disksPayload := make(map[string]interface{})
 	for id, d := range node.Disks {
 		disksPayload[id] = map[string]interface{}{
-			"path":            d.Path,
-			"allowScheduling": d.AllowScheduling,
-			"storageReserved": d.StorageReserved,
-			"tags":            d.Tags,
+			"path":              d.Path,
+			"allowScheduling":   d.AllowScheduling,
+			"evictionRequested": d.EvictionRequested,
+			"storageReserved":   d.StorageReserved,
+			"tags":              d.Tags,
 		}
 	}
 
@@ -279,23 +324,22 @@ func (c *nodeClient) updateDiskSchedulingViaNode(
 
 	// Update the node
 	path := fmt.Sprintf("/nodes/%s", nodeName)
-	resp, err := c.client.doRequest("PUT", path, updatePayload)
+	resp, err := c.client.doRequest(ctx, "PUT", path, updatePayload)
 	if err != nil {
 		return fmt.Errorf("failed to update node: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update disk scheduling: %s", string(body))
+		return fmt.Errorf("failed to update disk: %w", errorFromResponse(resp))
 	}
 
 	return nil
 }
 
 // AddNodeTag adds a tag to a node
-func (c *nodeClient) AddNodeTag(nodeName, tag string) error {
-	node, err := c.Get(nodeName)
+func (c *nodeClient) AddNodeTag(ctx context.Context, nodeName, tag string) error {
+	node, err := c.Get(ctx, nodeName)
 	if err != nil {
 		return err
 	}
@@ -309,13 +353,13 @@ func (c *nodeClient) AddNodeTag(nodeName, tag string) error {
 
 	tags := append(node.Tags, tag)
 	update := &NodeUpdate{Tags: tags}
-	_, err = c.Update(nodeName, update)
+	_, err = c.Update(ctx, nodeName, update)
 	return err
 }
 
 // RemoveNodeTag removes a tag from a node
-func (c *nodeClient) RemoveNodeTag(nodeName, tag string) error {
-	node, err := c.Get(nodeName)
+func (c *nodeClient) RemoveNodeTag(ctx context.Context, nodeName, tag string) error {
+	node, err := c.Get(ctx, nodeName)
 	if err != nil {
 		return err
 	}
@@ -329,7 +373,7 @@ func (c *nodeClient) RemoveNodeTag(nodeName, tag string) error {
 	}
 
 	update := &NodeUpdate{Tags: tags}
-	_, err = c.Update(nodeName, update)
+	_, err = c.Update(ctx, nodeName, update)
 	return err
 }
 
@@ -6,140 +6,64 @@ import (
 	"fmt"
 	"strings"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // AddDisk adds a new disk to a Longhorn node via CRD
-func (c *crdNodeClient) AddDisk(nodeName string, disk DiskUpdate) error {
-	// Get current node
-	currentUnstructured, err := c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), nodeName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
-	}
-
-	// Get the spec
-	spec, found, err := unstructured.NestedMap(currentUnstructured.Object, "spec")
-	if err != nil {
-		return fmt.Errorf("failed to get spec: %w", err)
-	}
-	if !found {
-		spec = make(map[string]interface{})
-	}
-
-	// Get existing disks or create new map
-	disks, found, err := unstructured.NestedMap(spec, "disks")
-	if err != nil {
-		return fmt.Errorf("failed to get disks: %w", err)
-	}
-	if !found || disks == nil {
-		disks = make(map[string]interface{})
-	}
-
+func (c *crdNodeClient) AddDisk(ctx context.Context, nodeName string, disk DiskUpdate) error {
 	// Generate disk ID based on path
 	diskID := fmt.Sprintf(
 		"disk-%s",
 		strings.ReplaceAll(strings.TrimPrefix(disk.Path, "/"), "/", "-"),
 	)
 
-	// Check if disk already exists
-	if _, exists := disks[diskID]; exists {
-		return fmt.Errorf("disk with path %s already exists", disk.Path)
-	}
-
-	// Create new disk entry
-	newDisk := map[string]interface{}{
-		"path":            disk.Path,
-		"allowScheduling": true, // Default to true for new disks
-		"storageReserved": disk.StorageReserved,
-	}
-
-	// Add tags if provided
-	if len(disk.Tags) > 0 {
-		tags := make([]interface{}, len(disk.Tags))
-		for i, tag := range disk.Tags {
-			tags[i] = tag
+	err := c.updateDisks(ctx, nodeName, true, func(disks map[string]interface{}) error {
+		// Check if disk already exists
+		if _, exists := disks[diskID]; exists {
+			return fmt.Errorf("disk with path %s already exists", disk.Path)
 		}
-		newDisk["tags"] = tags
-	}
 
-	// Add the new disk to the disks map
-	disks[diskID] = newDisk
-
-	// Update the spec with the new disks map
-	if err := unstructured.SetNestedMap(spec, disks, "disks"); err != nil {
-		return fmt.Errorf("failed to set disks: %w", err)
-	}
+		// Create new disk entry
+		newDisk := map[string]interface{}{
+			"path":            disk.Path,
+			"allowScheduling": true, // Default to true for new disks
+			"storageReserved": disk.StorageReserved,
+		}
 
-	// Set the updated spec back to the node
-	if err := unstructured.SetNestedMap(currentUnstructured.Object, spec, "spec"); err != nil {
-		return fmt.Errorf("failed to update spec: %w", err)
-	}
+		// Add tags if provided
+		if len(disk.Tags) > 0 {
+			newDisk["tags"] = stringsToInterfaces(disk.Tags)
+		}
 
-	// Update the node via CRD
-	_, err = c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Update(context.TODO(), currentUnstructured, metav1.UpdateOptions{})
+		// Add the new disk to the disks map
+		disks[diskID] = newDisk
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update node %s: %w", nodeName, err)
+		return err
 	}
 
 	debugLog("Successfully added disk %s to node %s", disk.Path, nodeName)
 	return nil
 }
 
-// RemoveDisk removes a disk from a Longhorn node via CRD
-func (c *crdNodeClient) RemoveDisk(nodeName, diskID string) error {
-	// Get current node
-	currentUnstructured, err := c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), nodeName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+// RemoveDisk removes a disk from a Longhorn node via CRD. Unless forced, a
+// disk with scheduled replicas is not removed and a DiskInUseError is
+// returned.
+func (c *crdNodeClient) RemoveDisk(
+	ctx context.Context,
+	nodeName, diskID string,
+	opts DiskRemoveOptions,
+) error {
+	var mutateErr error
+	_, err := c.crdClient.updateWithRetry(ctx, nodeGVR, nodeName,
+		func(node *unstructured.Unstructured) error {
+			mutateErr = removeDisk(node, diskID, opts)
+			return mutateErr
+		})
+	if mutateErr != nil {
+		return mutateErr
 	}
-
-	// Get the spec
-	spec, found, err := unstructured.NestedMap(currentUnstructured.Object, "spec")
-	if err != nil {
-		return fmt.Errorf("failed to get spec: %w", err)
-	}
-	if !found {
-		return fmt.Errorf("spec not found in node %s", nodeName)
-	}
-
-	// Get existing disks
-	disks, found, err := unstructured.NestedMap(spec, "disks")
-	if err != nil {
-		return fmt.Errorf("failed to get disks: %w", err)
-	}
-	if !found || disks == nil {
-		return fmt.Errorf("no disks found on node %s", nodeName)
-	}
-
-	// Check if disk exists
-	if _, exists := disks[diskID]; !exists {
-		return fmt.Errorf("disk %s not found on node %s", diskID, nodeName)
-	}
-
-	// Remove the disk
-	delete(disks, diskID)
-
-	// Update the spec with the modified disks map
-	if err := unstructured.SetNestedMap(spec, disks, "disks"); err != nil {
-		return fmt.Errorf("failed to set disks: %w", err)
-	}
-
-	// Set the updated spec back to the node
-	if err := unstructured.SetNestedMap(currentUnstructured.Object, spec, "spec"); err != nil {
-		return fmt.Errorf("failed to update spec: %w", err)
-	}
-
-	// Update the node via CRD
-	_, err = c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Update(context.TODO(), currentUnstructured, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to update node %s: %w", nodeName, err)
 	}
@@ -148,77 +72,54 @@ func (c *crdNodeClient) RemoveDisk(nodeName, diskID string) error {
 	return nil
 }
 
-// UpdateDiskTags updates tags for a specific disk on a Longhorn node via CRD
-func (c *crdNodeClient) UpdateDiskTags(nodeName, diskID string, tags []string) error {
-	// Get current node
-	currentUnstructured, err := c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), nodeName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
-	}
-
-	// Get the spec
-	spec, found, err := unstructured.NestedMap(currentUnstructured.Object, "spec")
-	if err != nil {
-		return fmt.Errorf("failed to get spec: %w", err)
-	}
-	if !found {
-		return fmt.Errorf("spec not found in node %s", nodeName)
+// removeDisk deletes a disk from the spec of a node object. Scheduled
+// replicas are checked on the object that is written back, so a replica
+// scheduled by a concurrent change is seen when the update is retried.
+func removeDisk(node *unstructured.Unstructured, diskID string, opts DiskRemoveOptions) error {
+	if !opts.Force {
+		current, err := unstructuredToNode(node)
+		if err != nil {
+			return err
+		}
+		if err := checkDiskUnused(current, diskID); err != nil {
+			return err
+		}
 	}
 
-	// Get existing disks
-	disks, found, err := unstructured.NestedMap(spec, "disks")
+	disks, found, err := unstructured.NestedMap(node.Object, "spec", "disks")
 	if err != nil {
 		return fmt.Errorf("failed to get disks: %w", err)
 	}
-	if !found || disks == nil {
-		return fmt.Errorf("no disks found on node %s", nodeName)
-	}
-
-	// Get the specific disk
-	diskData, exists := disks[diskID]
-	if !exists {
-		return fmt.Errorf("disk %s not found on node %s", diskID, nodeName)
-	}
-
-	// Convert disk data to map
-	disk, ok := diskData.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid disk data format for disk %s", diskID)
+	if !found {
+		return fmt.Errorf("no disks found on node %s", node.GetName())
 	}
-
-	// Update tags
-	if len(tags) > 0 {
-		tagList := make([]interface{}, len(tags))
-		for i, tag := range tags {
-			tagList[i] = tag
-		}
-		disk["tags"] = tagList
-	} else {
-		// Remove tags if empty list provided
-		delete(disk, "tags")
+	if _, exists := disks[diskID]; !exists {
+		return fmt.Errorf("disk %s not found on node %s", diskID, node.GetName())
 	}
 
-	// Update the disk in the disks map
-	disks[diskID] = disk
-
-	// Update the spec with the modified disks map
-	if err := unstructured.SetNestedMap(spec, disks, "disks"); err != nil {
+	delete(disks, diskID)
+	if err := unstructured.SetNestedMap(node.Object, disks, "spec", "disks"); err != nil {
 		return fmt.Errorf("failed to set disks: %w", err)
 	}
+	return nil
+}
 
-	// Set the updated spec back to the node
-	if err := unstructured.SetNestedMap(currentUnstructured.Object, spec, "spec"); err != nil {
-		return fmt.Errorf("failed to update spec: %w", err)
-	}
-
-	// Update the node via CRD
-	_, err = c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Update(context.TODO(), currentUnstructured, metav1.UpdateOptions{})
+// UpdateDiskTags updates tags for a specific disk on a Longhorn node via CRD
+func (c *crdNodeClient) UpdateDiskTags(
+	ctx context.Context,
+	nodeName, diskID string,
+	tags []string,
+) error {
+	err := c.updateDisk(ctx, nodeName, diskID, func(disk map[string]interface{}) {
+		if len(tags) > 0 {
+			disk["tags"] = stringsToInterfaces(tags)
+		} else {
+			// Remove tags if empty list provided
+			delete(disk, "tags")
+		}
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update node %s: %w", nodeName, err)
+		return err
 	}
 
 	debugLog("Successfully updated tags for disk %s on node %s", diskID, nodeName)
@@ -226,83 +127,107 @@ func (c *crdNodeClient) UpdateDiskTags(nodeName, diskID string, tags []string) e
 }
 
 // EnableDiskScheduling enables scheduling for a specific disk on a Longhorn node
-func (c *crdNodeClient) EnableDiskScheduling(nodeName, diskID string) error {
-	return c.updateDiskScheduling(nodeName, diskID, true)
+func (c *crdNodeClient) EnableDiskScheduling(ctx context.Context, nodeName, diskID string) error {
+	return c.updateDiskScheduling(ctx, nodeName, diskID, true)
 }
 
 // DisableDiskScheduling disables scheduling for a specific disk on a Longhorn node
-func (c *crdNodeClient) DisableDiskScheduling(nodeName, diskID string) error {
-	return c.updateDiskScheduling(nodeName, diskID, false)
+func (c *crdNodeClient) DisableDiskScheduling(ctx context.Context, nodeName, diskID string) error {
+	return c.updateDiskScheduling(ctx, nodeName, diskID, false)
 }
 
-// updateDiskScheduling is a helper function to update disk scheduling
-func (c *crdNodeClient) updateDiskScheduling(nodeName, diskID string, allowScheduling bool) error {
-	// Get current node
-	currentUnstructured, err := c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), nodeName, metav1.GetOptions{})
+// EvictDisk requests eviction of all replicas from a specific disk on a Longhorn node
+func (c *crdNodeClient) EvictDisk(ctx context.Context, nodeName, diskID string) error {
+	err := c.updateDisk(ctx, nodeName, diskID, func(disk map[string]interface{}) {
+		disk["evictionRequested"] = true
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+		return err
 	}
 
-	// Get the spec
-	spec, found, err := unstructured.NestedMap(currentUnstructured.Object, "spec")
-	if err != nil {
-		return fmt.Errorf("failed to get spec: %w", err)
-	}
-	if !found {
-		return fmt.Errorf("spec not found in node %s", nodeName)
-	}
+	debugLog("Successfully requested eviction for disk %s on node %s", diskID, nodeName)
+	return nil
+}
 
-	// Get existing disks
-	disks, found, err := unstructured.NestedMap(spec, "disks")
+// updateDiskScheduling is a helper function to update disk scheduling
+func (c *crdNodeClient) updateDiskScheduling(
+	ctx context.Context,
+	nodeName, diskID string,
+	allowScheduling bool,
+) error {
+	err := c.updateDisk(ctx, nodeName, diskID, func(disk map[string]interface{}) {
+		disk["allowScheduling"] = allowScheduling
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get disks: %w", err)
-	}
-	if !found || disks == nil {
-		return fmt.Errorf("no disks found on node %s", nodeName)
+		return err
 	}
 
-	// Get the specific disk
-	diskData, exists := disks[diskID]
-	if !exists {
-		return fmt.Errorf("disk %s not found on node %s", diskID, nodeName)
+	action := "enabled"
+	if !allowScheduling {
+		action = "disabled"
 	}
+	debugLog("Successfully %s scheduling for disk %s on node %s", action, diskID, nodeName)
+	return nil
+}
 
-	// Convert disk data to map
-	disk, ok := diskData.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("invalid disk data format for disk %s", diskID)
-	}
+// updateDisk applies mutate to the spec of a single disk and updates the node
+func (c *crdNodeClient) updateDisk(
+	ctx context.Context,
+	nodeName, diskID string,
+	mutate func(disk map[string]interface{}),
+) error {
+	return c.updateDisks(ctx, nodeName, false, func(disks map[string]interface{}) error {
+		// Get the specific disk
+		diskData, exists := disks[diskID]
+		if !exists {
+			return fmt.Errorf("disk %s not found on node %s", diskID, nodeName)
+		}
 
-	// Update allowScheduling
-	disk["allowScheduling"] = allowScheduling
+		// Convert disk data to map
+		disk, ok := diskData.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid disk data format for disk %s", diskID)
+		}
 
-	// Update the disk in the disks map
-	disks[diskID] = disk
+		mutate(disk)
 
-	// Update the spec with the modified disks map
-	if err := unstructured.SetNestedMap(spec, disks, "disks"); err != nil {
-		return fmt.Errorf("failed to set disks: %w", err)
-	}
+		// Update the disk in the disks map
+		disks[diskID] = disk
+		return nil
+	})
+}
 
-	// Set the updated spec back to the node
-	if err := unstructured.SetNestedMap(currentUnstructured.Object, spec, "spec"); err != nil {
-		return fmt.Errorf("failed to update spec: %w", err)
-	}
+// updateDisks applies mutate to the disks of a node and updates the node,
+// retrying when the node was changed concurrently. Unless create is set, a
+// node without disks is an error.
+func (c *crdNodeClient) updateDisks(
+	ctx context.Context,
+	nodeName string,
+	create bool,
+	mutate func(disks map[string]interface{}) error,
+) error {
+	_, err := c.updateSpec(ctx, nodeName, func(spec map[string]interface{}) error {
+		// Get existing disks
+		disks, found, err := unstructured.NestedMap(spec, "disks")
+		if err != nil {
+			return fmt.Errorf("failed to get disks: %w", err)
+		}
+		if !found || disks == nil {
+			if !create {
+				return fmt.Errorf("no disks found on node %s", nodeName)
+			}
+			disks = make(map[string]interface{})
+		}
 
-	// Update the node via CRD
-	_, err = c.crdClient.dynamicClient.Resource(nodeGVR).
-		Namespace(c.crdClient.namespace).
-		Update(context.TODO(), currentUnstructured, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to update node %s: %w", nodeName, err)
-	}
+		if err := mutate(disks); err != nil {
+			return err
+		}
 
-	action := "enabled"
-	if !allowScheduling {
-		action = "disabled"
-	}
-	debugLog("Successfully %s scheduling for disk %s on node %s", action, diskID, nodeName)
-	return nil
+		// Update the spec with the modified disks map
+		if err := unstructured.SetNestedMap(spec, disks, "disks"); err != nil {
+			return fmt.Errorf("failed to set disks: %w", err)
+		}
+		return nil
+	})
+	return err
 }
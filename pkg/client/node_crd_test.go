@@ -0,0 +1,104 @@
+// pkg/client/node_crd_test.go
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRemoveDisk(t *testing.T) {
+	inUse := map[string]interface{}{"vol-1-r-1": int64(1 << 30)}
+
+	tests := []struct {
+		name        string
+		replicas    map[string]interface{}
+		force       bool
+		wantInUse   bool
+		wantRemoved bool
+	}{
+		{name: "empty disk", replicas: map[string]interface{}{}, wantRemoved: true},
+		{name: "disk in use", replicas: inUse, wantInUse: true},
+		{name: "disk in use, forced", replicas: inUse, force: true, wantRemoved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crdClient, dynamicClient := newFakeCRDClient(testNode(tt.replicas))
+			nodes := &crdNodeClient{crdClient: crdClient}
+
+			err := nodes.RemoveDisk(context.Background(), "node-1", "disk-1",
+				DiskRemoveOptions{Force: tt.force})
+
+			var inUseErr *DiskInUseError
+			if errors.As(err, &inUseErr) != tt.wantInUse {
+				t.Fatalf("RemoveDisk() error = %v, want DiskInUseError %v", err, tt.wantInUse)
+			}
+			if !tt.wantInUse && err != nil {
+				t.Fatalf("RemoveDisk() error = %v", err)
+			}
+			if tt.wantInUse && inUseErr.Replicas != len(tt.replicas) {
+				t.Errorf("DiskInUseError.Replicas = %d, want %d", inUseErr.Replicas, len(tt.replicas))
+			}
+
+			node, err := dynamicClient.Resource(nodeGVR).Namespace("longhorn-system").
+				Get(context.Background(), "node-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, found, _ := unstructured.NestedMap(node.Object, "spec", "disks", "disk-1")
+			if found == tt.wantRemoved {
+				t.Errorf("disk present = %v, want removed %v", found, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestRemoveDiskReplicaScheduledConcurrently(t *testing.T) {
+	crdClient, dynamicClient := newFakeCRDClient(testNode(map[string]interface{}{}))
+	nodes := &crdNodeClient{crdClient: crdClient}
+
+	// Longhorn schedules a replica on the disk after it was read empty, so
+	// the first update conflicts
+	updates := 0
+	dynamicClient.PrependReactor("update", "nodes",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updates++
+			if updates > 1 {
+				return false, nil, nil
+			}
+
+			scheduled := testNode(map[string]interface{}{"vol-1-r-1": int64(1 << 30)})
+			err := dynamicClient.Tracker().Update(nodeGVR, scheduled, "longhorn-system")
+			if err != nil {
+				return true, nil, err
+			}
+			return true, nil, apierrors.NewConflict(nodeGVR.GroupResource(), "node-1",
+				errors.New("the object has been modified"))
+		})
+
+	err := nodes.RemoveDisk(context.Background(), "node-1", "disk-1", DiskRemoveOptions{})
+
+	var inUseErr *DiskInUseError
+	if !errors.As(err, &inUseErr) {
+		t.Fatalf("RemoveDisk() error = %v, want DiskInUseError", err)
+	}
+	if updates != 1 {
+		t.Errorf("updates = %d, want 1", updates)
+	}
+
+	node, err := dynamicClient.Resource(nodeGVR).Namespace("longhorn-system").
+		Get(context.Background(), "node-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := unstructured.NestedMap(node.Object, "spec", "disks", "disk-1"); !found {
+		t.Error("disk was removed")
+	}
+}
@@ -0,0 +1,89 @@
+// pkg/client/orphan_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// orphanClient implementation for CRDs
+type crdOrphanClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns all orphans
+func (c *crdOrphanClient) List(ctx context.Context) ([]Orphan, error) {
+	debugLog("Listing Longhorn orphans via CRD")
+
+	list, err := c.crdClient.dynamicClient.Resource(orphanGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orphans: %w", err)
+	}
+
+	orphans := make([]Orphan, 0, len(list.Items))
+	for _, item := range list.Items {
+		orphans = append(orphans, *unstructuredToOrphan(&item))
+	}
+
+	return orphans, nil
+}
+
+// Get returns a specific orphan
+func (c *crdOrphanClient) Get(ctx context.Context, name string) (*Orphan, error) {
+	debugLog("Getting Longhorn orphan %s via CRD", name)
+
+	unstructuredOrphan, err := c.crdClient.dynamicClient.Resource(orphanGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orphan %s: %w", name, err)
+	}
+
+	return unstructuredToOrphan(unstructuredOrphan), nil
+}
+
+// Delete deletes an orphan. Longhorn removes the orphaned data from the disk.
+func (c *crdOrphanClient) Delete(ctx context.Context, name string) error {
+	debugLog("Deleting Longhorn orphan %s via CRD", name)
+
+	err := c.crdClient.dynamicClient.Resource(orphanGVR).
+		Namespace(c.crdClient.namespace).
+		Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete orphan %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Helper function to convert unstructured to Orphan
+func unstructuredToOrphan(u *unstructured.Unstructured) *Orphan {
+	orphan := &Orphan{
+		Name: u.GetName(),
+	}
+
+	if spec, found, err := unstructured.NestedMap(u.Object, "spec"); err == nil && found {
+		if v, ok := spec["nodeID"].(string); ok {
+			orphan.NodeID = v
+		}
+		if v, ok := spec["orphanType"].(string); ok {
+			orphan.Type = v
+		}
+		parameters := stringMap(spec["parameters"])
+		orphan.DataName = parameters["DataName"]
+		orphan.DiskName = parameters["DiskName"]
+		orphan.DiskUUID = parameters["DiskUUID"]
+		orphan.DiskPath = parameters["DiskPath"]
+	}
+
+	if conditions, found, _ := unstructured.NestedSlice(u.Object, "status", "conditions"); found {
+		orphan.Conditions = unstructuredConditions(conditions)
+	}
+
+	return orphan
+}
@@ -0,0 +1,61 @@
+// pkg/client/pvc.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResolvePVC returns the name of the Longhorn volume bound to a PVC. With a
+// Kubernetes connection the PVC's PV is looked up and its CSI volumeHandle
+// is used; otherwise the Kubernetes status Longhorn records on the volumes
+// is searched.
+func (c *Client) ResolvePVC(ctx context.Context, namespace, name string) (string, error) {
+	if c.crdClient != nil && c.crdClient.kubeClient != nil {
+		return c.resolvePVCFromPV(ctx, namespace, name)
+	}
+
+	debugLog("Resolving PVC %s/%s from volume Kubernetes status", namespace, name)
+
+	volumes, err := c.Volumes().List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list volumes: %w", err)
+	}
+	for _, volume := range volumes {
+		status := volume.KubernetesStatus
+		if status.Namespace == namespace && status.PVCName == name {
+			return volume.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no Longhorn volume is bound to PVC %s/%s", namespace, name)
+}
+
+// resolvePVCFromPV follows a PVC to its PV and the Longhorn volume behind it
+func (c *Client) resolvePVCFromPV(ctx context.Context, namespace, name string) (string, error) {
+	debugLog("Resolving PVC %s/%s via Kubernetes API", namespace, name)
+
+	kubeClient := c.crdClient.kubeClient
+	pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get PVC %s/%s: %w", namespace, name, err)
+	}
+	if pvc.Spec.VolumeName == "" {
+		return "", fmt.Errorf("PVC %s/%s is not bound to a PV", namespace, name)
+	}
+
+	pv, err := kubeClient.CoreV1().PersistentVolumes().
+		Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get PV %s: %w", pvc.Spec.VolumeName, err)
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != LonghornCSIDriver {
+		return "", fmt.Errorf("PV %s of PVC %s/%s is not a Longhorn volume",
+			pv.Name, namespace, name)
+	}
+
+	return pv.Spec.CSI.VolumeHandle, nil
+}
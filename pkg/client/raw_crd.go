@@ -0,0 +1,74 @@
+// pkg/client/raw_crd.go
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ErrKubernetesRequired is returned by operations that only work through the
+// Kubernetes API
+var ErrKubernetesRequired = errors.New("a Kubernetes connection (kubeconfig) is required")
+
+// Kubernetes core pods
+var podGVR = schema.GroupVersionResource{
+	Group:    "",
+	Version:  "v1",
+	Resource: "pods",
+}
+
+// rawResources are the resources that can be read with ListRaw
+var rawResources = map[string]schema.GroupVersionResource{
+	"volumes":          volumeGVR,
+	"engines":          engineGVR,
+	"replicas":         replicaGVR,
+	"nodes":            nodeGVR,
+	"settings":         settingGVR,
+	"backups":          backupGVR,
+	"backuptargets":    backupTargetGVR,
+	"recurringjobs":    recurringJobGVR,
+	"instancemanagers": instanceManagerGVR,
+	"engineimages":     engineImageGVR,
+	"orphans":          orphanGVR,
+	"sharemanagers":    shareManagerGVR,
+	"events":           eventGVR,
+	"pods":             podGVR,
+}
+
+// ListRaw returns the objects of a resource in the Longhorn namespace as they
+// are stored in Kubernetes. Resources are named by their plural, lowercase
+// Kubernetes name, e.g. "volumes", "instancemanagers" or "pods".
+func (c *Client) ListRaw(
+	ctx context.Context,
+	resource string,
+) ([]unstructured.Unstructured, error) {
+	if c.crdClient == nil {
+		return nil, ErrKubernetesRequired
+	}
+
+	gvr, ok := rawResources[resource]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource %q", resource)
+	}
+
+	debugLog("Listing %s via Kubernetes API", resource)
+
+	list, err := c.crdClient.dynamicClient.Resource(gvr).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+
+	return list.Items, nil
+}
+
+// Namespace returns the namespace Longhorn is installed in
+func (c *Client) Namespace() string {
+	return c.config.Namespace
+}
@@ -0,0 +1,247 @@
+// pkg/client/readonly.go
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// ErrReadOnly is returned when changing resources through a read-only client
+var ErrReadOnly = errors.New("read-only client: captured data cannot be changed")
+
+// NewReadOnlyClient creates a client that serves the given objects, e.g. the
+// resources captured in a support bundle, instead of a live cluster. All
+// read operations work as with a Kubernetes connection; changes fail with
+// ErrReadOnly.
+func NewReadOnlyClient(namespace string, objects []unstructured.Unstructured) (*Client, error) {
+	if namespace == "" {
+		namespace = "longhorn-system"
+	}
+
+	store, err := newReadOnlyStore(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		config: &Config{
+			Namespace: namespace,
+		},
+		baseURL: "file://",
+		crdClient: &LonghornCRDClient{
+			dynamicClient:   store,
+			watchClient:     store,
+			namespace:       namespace,
+			conflictRetries: DefaultConflictRetries,
+		},
+	}, nil
+}
+
+// readOnlyStore is a dynamic client over a fixed set of objects. It answers
+// gets and lists, including label and field selectors, and never reports
+// changes to watches.
+type readOnlyStore struct {
+	resources map[schema.GroupResource][]*unstructured.Unstructured
+}
+
+// newReadOnlyStore sorts objects by resource. Objects are found by group and
+// resource, so any version of a resource serves the captured objects.
+func newReadOnlyStore(objects []unstructured.Unstructured) (*readOnlyStore, error) {
+	store := &readOnlyStore{resources: make(map[schema.GroupResource][]*unstructured.Unstructured)}
+	seen := make(map[string]bool)
+
+	for i := range objects {
+		obj := &objects[i]
+		gvk := obj.GroupVersionKind()
+		if gvk.Kind == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("failed to load objects: object %d has no kind or name", i)
+		}
+
+		plural, _ := meta.UnsafeGuessKindToResource(gvk)
+		resource := plural.GroupResource()
+
+		key := resource.String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+		if seen[key] {
+			return nil, fmt.Errorf("failed to load objects: duplicate %s %s",
+				gvk.Kind, obj.GetName())
+		}
+		seen[key] = true
+
+		store.resources[resource] = append(store.resources[resource], obj)
+	}
+	return store, nil
+}
+
+// Resource returns the captured objects of a resource
+func (s *readOnlyStore) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &readOnlyResource{store: s, gvr: gvr}
+}
+
+// readOnlyResource serves the objects of one resource, optionally limited
+// to a namespace
+type readOnlyResource struct {
+	store     *readOnlyStore
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+func (r *readOnlyResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &readOnlyResource{store: r.store, gvr: r.gvr, namespace: namespace}
+}
+
+func (r *readOnlyResource) Get(
+	ctx context.Context,
+	name string,
+	options metav1.GetOptions,
+	subresources ...string,
+) (*unstructured.Unstructured, error) {
+	for _, obj := range r.store.resources[r.gvr.GroupResource()] {
+		if obj.GetName() == name && (r.namespace == "" || obj.GetNamespace() == r.namespace) {
+			return obj.DeepCopy(), nil
+		}
+	}
+	return nil, apierrors.NewNotFound(r.gvr.GroupResource(), name)
+}
+
+func (r *readOnlyResource) List(
+	ctx context.Context,
+	opts metav1.ListOptions,
+) (*unstructured.UnstructuredList, error) {
+	labelSelector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	fieldSelector, err := fields.ParseSelector(opts.FieldSelector)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(r.gvr.GroupVersion().String())
+	list.SetResourceVersion("1")
+
+	for _, obj := range r.store.resources[r.gvr.GroupResource()] {
+		if r.namespace != "" && obj.GetNamespace() != r.namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(obj.GetLabels())) ||
+			!fieldSelector.Matches(objectFields(obj, fieldSelector)) {
+			continue
+		}
+		list.Items = append(list.Items, *obj.DeepCopy())
+	}
+	return list, nil
+}
+
+// Watch returns a watch that reports nothing until ctx is cancelled, as
+// captured objects never change
+func (r *readOnlyResource) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	watcher := watch.NewFake()
+	go func() {
+		<-ctx.Done()
+		watcher.Stop()
+	}()
+	return watcher, nil
+}
+
+func (r *readOnlyResource) Create(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	options metav1.CreateOptions,
+	subresources ...string,
+) (*unstructured.Unstructured, error) {
+	return nil, ErrReadOnly
+}
+
+func (r *readOnlyResource) Update(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	options metav1.UpdateOptions,
+	subresources ...string,
+) (*unstructured.Unstructured, error) {
+	return nil, ErrReadOnly
+}
+
+func (r *readOnlyResource) UpdateStatus(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	options metav1.UpdateOptions,
+) (*unstructured.Unstructured, error) {
+	return nil, ErrReadOnly
+}
+
+func (r *readOnlyResource) Delete(
+	ctx context.Context,
+	name string,
+	options metav1.DeleteOptions,
+	subresources ...string,
+) error {
+	return ErrReadOnly
+}
+
+func (r *readOnlyResource) DeleteCollection(
+	ctx context.Context,
+	options metav1.DeleteOptions,
+	listOptions metav1.ListOptions,
+) error {
+	return ErrReadOnly
+}
+
+func (r *readOnlyResource) Patch(
+	ctx context.Context,
+	name string,
+	pt types.PatchType,
+	data []byte,
+	options metav1.PatchOptions,
+	subresources ...string,
+) (*unstructured.Unstructured, error) {
+	return nil, ErrReadOnly
+}
+
+func (r *readOnlyResource) Apply(
+	ctx context.Context,
+	name string,
+	obj *unstructured.Unstructured,
+	options metav1.ApplyOptions,
+	subresources ...string,
+) (*unstructured.Unstructured, error) {
+	return nil, ErrReadOnly
+}
+
+func (r *readOnlyResource) ApplyStatus(
+	ctx context.Context,
+	name string,
+	obj *unstructured.Unstructured,
+	options metav1.ApplyOptions,
+) (*unstructured.Unstructured, error) {
+	return nil, ErrReadOnly
+}
+
+// objectFields returns the fields of obj a field selector refers to, e.g.
+// "metadata.name" or "involvedObject.name"
+func objectFields(obj *unstructured.Unstructured, selector fields.Selector) fields.Set {
+	set := fields.Set{}
+	for _, requirement := range selector.Requirements() {
+		value, found, err := unstructured.NestedFieldNoCopy(
+			obj.Object,
+			strings.Split(requirement.Field, ".")...,
+		)
+		if err == nil && found && value != nil {
+			set[requirement.Field] = fmt.Sprint(value)
+		}
+	}
+	return set
+}
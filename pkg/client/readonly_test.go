@@ -0,0 +1,185 @@
+// pkg/client/readonly_test.go
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func readOnlyObjects() []unstructured.Unstructured {
+	return []unstructured.Unstructured{
+		{Object: map[string]interface{}{
+			"apiVersion": "longhorn.io/v1beta2",
+			"kind":       "Replica",
+			"metadata": map[string]interface{}{
+				"name":      "vol-1-r-1",
+				"namespace": "longhorn-system",
+				"labels":    map[string]interface{}{"longhornvolume": "vol-1"},
+			},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "longhorn.io/v1beta2",
+			"kind":       "Replica",
+			"metadata": map[string]interface{}{
+				"name":      "vol-2-r-1",
+				"namespace": "longhorn-system",
+				"labels":    map[string]interface{}{"longhornvolume": "vol-2"},
+			},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Event",
+			"metadata": map[string]interface{}{
+				"name":      "vol-1.1",
+				"namespace": "longhorn-system",
+			},
+			"type":           "Warning",
+			"involvedObject": map[string]interface{}{"name": "vol-1"},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Event",
+			"metadata": map[string]interface{}{
+				"name":      "vol-2.1",
+				"namespace": "longhorn-system",
+			},
+			"type":           "Normal",
+			"involvedObject": map[string]interface{}{"name": "vol-2"},
+		}},
+	}
+}
+
+func TestReadOnlyStoreList(t *testing.T) {
+	store, err := newReadOnlyStore(readOnlyObjects())
+	if err != nil {
+		t.Fatalf("newReadOnlyStore() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		resource  *readOnlyResource
+		opts      metav1.ListOptions
+		wantNames []string
+	}{
+		{
+			name:      "all replicas",
+			resource:  &readOnlyResource{store: store, gvr: replicaGVR},
+			wantNames: []string{"vol-1-r-1", "vol-2-r-1"},
+		},
+		{
+			name:      "label selector",
+			resource:  &readOnlyResource{store: store, gvr: replicaGVR},
+			opts:      metav1.ListOptions{LabelSelector: "longhornvolume=vol-2"},
+			wantNames: []string{"vol-2-r-1"},
+		},
+		{
+			name:      "field selector on a nested field",
+			resource:  &readOnlyResource{store: store, gvr: eventGVR},
+			opts:      metav1.ListOptions{FieldSelector: "involvedObject.name=vol-1"},
+			wantNames: []string{"vol-1.1"},
+		},
+		{
+			name:     "field selector with several fields",
+			resource: &readOnlyResource{store: store, gvr: eventGVR},
+			opts: metav1.ListOptions{
+				FieldSelector: "involvedObject.name=vol-2,type=Warning",
+			},
+		},
+		{
+			name:     "other namespace",
+			resource: &readOnlyResource{store: store, gvr: replicaGVR, namespace: "default"},
+		},
+		{
+			name:     "resource without objects",
+			resource: &readOnlyResource{store: store, gvr: volumeGVR},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := tt.resource.List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var names []string
+			for _, item := range list.Items {
+				names = append(names, item.GetName())
+			}
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("List() = %v, want %v", names, tt.wantNames)
+			}
+			for i := range names {
+				if names[i] != tt.wantNames[i] {
+					t.Errorf("List() = %v, want %v", names, tt.wantNames)
+				}
+			}
+		})
+	}
+}
+
+func TestReadOnlyStoreGet(t *testing.T) {
+	store, err := newReadOnlyStore(readOnlyObjects())
+	if err != nil {
+		t.Fatalf("newReadOnlyStore() error = %v", err)
+	}
+	replicas := store.Resource(replicaGVR).Namespace("longhorn-system")
+
+	replica, err := replicas.Get(context.Background(), "vol-1-r-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	replica.SetLabels(nil)
+
+	again, err := replicas.Get(context.Background(), "vol-1-r-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if again.GetLabels()["longhornvolume"] != "vol-1" {
+		t.Error("Get() returned the stored object instead of a copy")
+	}
+
+	_, err = replicas.Get(context.Background(), "missing", metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Get() of a missing object error = %v, want NotFound", err)
+	}
+}
+
+func TestReadOnlyStoreRejectsChanges(t *testing.T) {
+	store, err := newReadOnlyStore(readOnlyObjects())
+	if err != nil {
+		t.Fatalf("newReadOnlyStore() error = %v", err)
+	}
+	replicas := store.Resource(replicaGVR).Namespace("longhorn-system")
+	ctx := context.Background()
+	obj := &readOnlyObjects()[0]
+
+	_, createErr := replicas.Create(ctx, obj, metav1.CreateOptions{})
+	_, updateErr := replicas.Update(ctx, obj, metav1.UpdateOptions{})
+	_, patchErr := replicas.Patch(ctx, obj.GetName(), "", nil, metav1.PatchOptions{})
+	deleteErr := replicas.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+
+	for name, err := range map[string]error{
+		"Create": createErr,
+		"Update": updateErr,
+		"Patch":  patchErr,
+		"Delete": deleteErr,
+	} {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s() error = %v, want ErrReadOnly", name, err)
+		}
+	}
+}
+
+func TestReadOnlyStoreDuplicate(t *testing.T) {
+	objects := readOnlyObjects()
+	objects = append(objects, *objects[0].DeepCopy())
+
+	if _, err := newReadOnlyStore(objects); err == nil {
+		t.Error("newReadOnlyStore() with a duplicate object succeeded")
+	}
+}
@@ -2,6 +2,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,17 +14,17 @@ type replicaClient struct {
 }
 
 // List returns all replicas
-func (c *replicaClient) List() ([]Replica, error) {
+func (c *replicaClient) List(ctx context.Context) ([]Replica, error) {
 	debugLog("Listing replicas via HTTP")
 
-	resp, err := c.client.doRequest("GET", "/replicas", nil)
+	resp, err := c.client.doRequest(ctx, "GET", "/replicas", nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, errorFromResponse(resp)
 	}
 
 	var result struct {
@@ -37,21 +38,21 @@ func (c *replicaClient) List() ([]Replica, error) {
 }
 
 // Get returns a specific replica
-func (c *replicaClient) Get(name string) (*Replica, error) {
+func (c *replicaClient) Get(ctx context.Context, name string) (*Replica, error) {
 	debugLog("Getting replica: %s", name)
 
-	resp, err := c.client.doRequest("GET", fmt.Sprintf("/replicas/%s", name), nil)
+	resp, err := c.client.doRequest(ctx, "GET", fmt.Sprintf("/replicas/%s", name), nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("replica %s not found", name)
+		return nil, fmt.Errorf("replica %s %w", name, ErrNotFound)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, errorFromResponse(resp)
 	}
 
 	var replica Replica
@@ -63,17 +64,17 @@ func (c *replicaClient) Get(name string) (*Replica, error) {
 }
 
 // Delete deletes a replica
-func (c *replicaClient) Delete(name string) error {
+func (c *replicaClient) Delete(ctx context.Context, name string) error {
 	debugLog("Deleting replica: %s", name)
 
-	resp, err := c.client.doRequest("DELETE", fmt.Sprintf("/replicas/%s", name), nil)
+	resp, err := c.client.doRequest(ctx, "DELETE", fmt.Sprintf("/replicas/%s", name), nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return errorFromResponse(resp)
 	}
 
 	return nil
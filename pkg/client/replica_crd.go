@@ -14,12 +14,12 @@ type crdReplicaClient struct {
 }
 
 // List returns all Longhorn replicas
-func (c *crdReplicaClient) List() ([]Replica, error) {
+func (c *crdReplicaClient) List(ctx context.Context) ([]Replica, error) {
 	debugLog("Listing Longhorn replicas via CRD")
 
 	list, err := c.crdClient.dynamicClient.Resource(replicaGVR).
 		Namespace(c.crdClient.namespace).
-		List(context.TODO(), metav1.ListOptions{})
+		List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list replicas: %w", err)
 	}
@@ -42,12 +42,12 @@ func (c *crdReplicaClient) List() ([]Replica, error) {
 }
 
 // Get returns a specific replica
-func (c *crdReplicaClient) Get(name string) (*Replica, error) {
+func (c *crdReplicaClient) Get(ctx context.Context, name string) (*Replica, error) {
 	debugLog("Getting Longhorn replica %s via CRD", name)
 
 	unstructuredReplica, err := c.crdClient.dynamicClient.Resource(replicaGVR).
 		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), name, metav1.GetOptions{})
+		Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get replica %s: %w", name, err)
 	}
@@ -64,12 +64,12 @@ func (c *crdReplicaClient) Get(name string) (*Replica, error) {
 }
 
 // Delete deletes a replica
-func (c *crdReplicaClient) Delete(name string) error {
+func (c *crdReplicaClient) Delete(ctx context.Context, name string) error {
 	debugLog("Deleting Longhorn replica %s via CRD", name)
 
 	err := c.crdClient.dynamicClient.Resource(replicaGVR).
 		Namespace(c.crdClient.namespace).
-		Delete(context.TODO(), name, metav1.DeleteOptions{})
+		Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete replica %s: %w", name, err)
 	}
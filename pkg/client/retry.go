@@ -0,0 +1,227 @@
+// pkg/client/retry.go
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how requests that failed with a transient error are
+// retried: rate limiting, server errors, dropped connections and etcd
+// leader changes. Waits grow exponentially with jitter and honour the
+// Retry-After header.
+type RetryPolicy struct {
+	MaxRetries     int           // Retries after the first attempt, 0 disables retries
+	InitialBackoff time.Duration // Wait before the first retry
+	MaxBackoff     time.Duration // Upper limit of the wait between retries
+}
+
+// DefaultRetryPolicy is used unless a context configures its own policy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// retryPolicyOrDefault returns policy, or DefaultRetryPolicy if it is nil
+func retryPolicyOrDefault(policy *RetryPolicy) RetryPolicy {
+	if policy == nil {
+		return DefaultRetryPolicy
+	}
+	return *policy
+}
+
+// maxRetryAfter limits how long a Retry-After header can delay a retry
+const maxRetryAfter = time.Minute
+
+// backoff returns the wait before the given retry, starting at 1. Half of
+// the wait is random so that clients do not retry in lockstep.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// retryTransport retries requests that failed with a transient error. It is
+// used by both the Longhorn manager API client and the Kubernetes clients.
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+
+	// clientGo is set for Kubernetes clients. client-go retries responses
+	// with a Retry-After header and failed GET connections itself, up to ten
+	// times, so these are left to it instead of being retried twice.
+	clientGo bool
+}
+
+// newRetryTransport wraps next with the retry policy
+func newRetryTransport(next http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if policy.MaxRetries <= 0 {
+		return next
+	}
+	return &retryTransport{next: next, policy: policy}
+}
+
+// newKubeRetryTransport wraps the transport of a Kubernetes client with the
+// retry policy, leaving the retries client-go makes itself to client-go
+func newKubeRetryTransport(next http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	rt := newRetryTransport(next, policy)
+	if t, ok := rt.(*retryTransport); ok {
+		t.clientGo = true
+	}
+	return rt
+}
+
+// RoundTrip sends a request and retries it while it fails transiently. A
+// request whose body cannot be sent again is not retried.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for retry := 1; ; retry++ {
+		resp, err := t.next.RoundTrip(req)
+
+		if retry > t.policy.MaxRetries || !canResend(req) {
+			return resp, err
+		}
+		reason, retryable := retryReason(req, resp, err)
+		if !retryable || (t.clientGo && retriedByClientGo(req, resp, err)) {
+			return resp, err
+		}
+
+		wait := t.wait(retry, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		debugLog("%s %s failed (%s), retrying in %s (%d/%d)",
+			req.Method, req.URL, reason, wait.Round(time.Millisecond), retry, t.policy.MaxRetries)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// wait returns how long to wait before the given retry: the backoff of the
+// policy, or longer if the response asks for it with Retry-After
+func (t *retryTransport) wait(retry int, resp *http.Response) time.Duration {
+	wait := t.policy.backoff(retry)
+	if resp == nil {
+		return wait
+	}
+	if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After")); retryAfter > wait {
+		wait = min(retryAfter, maxRetryAfter)
+	}
+	return wait
+}
+
+// retriedByClientGo reports whether client-go retries a failed request
+// itself
+func retriedByClientGo(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return req.Method == http.MethodGet
+	}
+	return resp.Header.Get("Retry-After") != "" &&
+		(resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500)
+}
+
+// canResend reports whether the body of a request can be sent again
+func canResend(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// retryReason reports whether a request failed transiently and why.
+// Requests that change state are only retried when the server did not
+// process them: on rate limiting, an unavailable server or an etcd leader
+// change. Idempotent requests are also retried on other server errors and
+// dropped connections.
+func retryReason(req *http.Request, resp *http.Response, err error) (string, bool) {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead ||
+		req.Method == http.MethodPut || req.Method == http.MethodDelete
+
+	if err != nil {
+		if req.Context().Err() != nil {
+			return "", false
+		}
+		if idempotent && isConnectionError(err) {
+			return err.Error(), true
+		}
+		return "", false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusServiceUnavailable:
+		return resp.Status, true
+	case resp.StatusCode == http.StatusInternalServerError && isLeaderChange(resp):
+		return "etcd leader changed", true
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return resp.Status, idempotent
+	}
+	return "", false
+}
+
+// isConnectionError reports whether err means the connection was dropped
+// or refused, as load balancers in front of API servers do
+func isConnectionError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isLeaderChange reports whether an internal server error was caused by an
+// etcd leader election. The body is read and put back for the caller.
+func isLeaderChange(resp *http.Response) bool {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(body, []byte("etcdserver: leader changed"))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as a date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
@@ -0,0 +1,320 @@
+// pkg/client/retry_test.go
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy retries without noticeable waits
+var fastRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+// reply is a response a test server sends for one attempt
+type reply struct {
+	status     int
+	retryAfter string
+	body       string
+}
+
+// replyServer answers the attempts of a request with the given replies in
+// turn, then with 200 OK, and records the bodies it received
+type replyServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	replies []reply
+	bodies  []string
+}
+
+func newReplyServer(t *testing.T, replies ...reply) *replyServer {
+	s := &replyServer{replies: replies}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mu.Lock()
+		attempt := len(s.bodies)
+		s.bodies = append(s.bodies, string(body))
+		s.mu.Unlock()
+
+		if attempt >= len(s.replies) {
+			io.WriteString(w, "ok")
+			return
+		}
+		reply := s.replies[attempt]
+		if reply.retryAfter != "" {
+			w.Header().Set("Retry-After", reply.retryAfter)
+		}
+		w.WriteHeader(reply.status)
+		io.WriteString(w, reply.body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *replyServer) attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.bodies)
+}
+
+func TestRetryTransport(t *testing.T) {
+	leaderChanged := `{"kind":"Status","message":"etcdserver: leader changed"}`
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		policy       RetryPolicy
+		clientGo     bool
+		replies      []reply
+		wantStatus   int
+		wantAttempts int
+		wantBody     string
+	}{
+		{
+			name:         "429 is retried",
+			method:       http.MethodPost,
+			replies:      []reply{{status: http.StatusTooManyRequests}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "503 is retried",
+			method:       http.MethodGet,
+			replies:      []reply{{status: 503}, {status: 503}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "500 on GET is retried",
+			method:       http.MethodGet,
+			replies:      []reply{{status: 500}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "500 on POST is not retried",
+			method:       http.MethodPost,
+			replies:      []reply{{status: 500, body: "boom"}},
+			wantStatus:   500,
+			wantAttempts: 1,
+			wantBody:     "boom",
+		},
+		{
+			name:         "501 is not retried",
+			method:       http.MethodGet,
+			replies:      []reply{{status: 501}},
+			wantStatus:   501,
+			wantAttempts: 1,
+		},
+		{
+			name:         "leader change on POST is retried",
+			method:       http.MethodPost,
+			replies:      []reply{{status: 500, body: leaderChanged}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:   "last failure is returned with its body",
+			method: http.MethodPost,
+			policy: RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond},
+			replies: []reply{
+				{status: 500, body: leaderChanged},
+				{status: 500, body: leaderChanged},
+			},
+			wantStatus:   500,
+			wantAttempts: 2,
+			wantBody:     leaderChanged,
+		},
+		{
+			name:         "body is sent again",
+			method:       http.MethodPost,
+			body:         `{"name":"vol"}`,
+			replies:      []reply{{status: 503}, {status: 429}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "Retry-After is left to client-go",
+			method:       http.MethodGet,
+			clientGo:     true,
+			replies:      []reply{{status: 503, retryAfter: "1"}},
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+		{
+			name:         "client-go transport retries without Retry-After",
+			method:       http.MethodPut,
+			clientGo:     true,
+			replies:      []reply{{status: 503}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newReplyServer(t, tt.replies...)
+
+			policy := tt.policy
+			if policy.MaxRetries == 0 {
+				policy = fastRetryPolicy
+			}
+			newTransport := newRetryTransport
+			if tt.clientGo {
+				newTransport = newKubeRetryTransport
+			}
+			client := &http.Client{Transport: newTransport(http.DefaultTransport, policy)}
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(tt.method, server.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			defer resp.Body.Close()
+			respBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := server.attempts(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if tt.wantBody != "" && string(respBody) != tt.wantBody {
+				t.Errorf("body = %q, want %q", respBody, tt.wantBody)
+			}
+			for i, sent := range server.bodies {
+				if sent != tt.body {
+					t.Errorf("attempt %d sent body %q, want %q", i+1, sent, tt.body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	server := newReplyServer(t, reply{status: http.StatusTooManyRequests, retryAfter: "1"})
+	client := &http.Client{Transport: newRetryTransport(nil, fastRetryPolicy)}
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least 1s", elapsed)
+	}
+}
+
+func TestRetryTransportWait(t *testing.T) {
+	transport := &retryTransport{policy: fastRetryPolicy}
+	date := time.Now().Add(20 * time.Second).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "backoff", max: time.Millisecond},
+		{name: "seconds", retryAfter: "5", min: 5 * time.Second, max: 5 * time.Second},
+		{name: "date", retryAfter: date, min: 18 * time.Second, max: 20 * time.Second},
+		{name: "capped", retryAfter: "3600", min: maxRetryAfter, max: maxRetryAfter},
+		{name: "past date", retryAfter: "Mon, 02 Jan 2006 15:04:05 GMT", max: time.Millisecond},
+		{name: "invalid", retryAfter: "soon", max: time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			wait := transport.wait(1, resp)
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("wait = %v, want between %v and %v", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestRetryTransportCancelDuringBackoff(t *testing.T) {
+	server := newReplyServer(t, reply{status: http.StatusServiceUnavailable})
+	policy := RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+	client := &http.Client{Transport: newRetryTransport(nil, policy)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = client.Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Do() returned after %v, want it to stop on cancel", elapsed)
+	}
+	if got := server.attempts(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestRetryReasonKeepsBody(t *testing.T) {
+	body := `{"message":"etcdserver: leader changed"}`
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	resp := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	if _, retryable := retryReason(req, resp, nil); !retryable {
+		t.Error("leader change is not retryable")
+	}
+	got, err := io.ReadAll(resp.Body)
+	if err != nil || string(got) != body {
+		t.Errorf("body after retryReason = %q, %v; want %q", got, err, body)
+	}
+}
+
+func TestNewRetryTransportDisabled(t *testing.T) {
+	inner := &http.Transport{}
+
+	if got := newRetryTransport(inner, RetryPolicy{MaxRetries: 0}); got != inner {
+		t.Errorf("newRetryTransport() = %T, want the inner transport", got)
+	}
+	if got := newKubeRetryTransport(inner, RetryPolicy{MaxRetries: 0}); got != inner {
+		t.Errorf("newKubeRetryTransport() = %T, want the inner transport", got)
+	}
+}
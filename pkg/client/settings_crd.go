@@ -0,0 +1,84 @@
+// pkg/client/settings_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// settingsClient implementation for CRDs
+type crdSettingsClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns all Longhorn settings keyed by name
+func (c *crdSettingsClient) List(ctx context.Context) (map[string]Setting, error) {
+	debugLog("Listing Longhorn settings via CRD")
+
+	list, err := c.crdClient.dynamicClient.Resource(settingGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings: %w", err)
+	}
+
+	settings := make(map[string]Setting, len(list.Items))
+	for _, item := range list.Items {
+		setting := unstructuredToSetting(&item)
+		settings[setting.Name] = *setting
+	}
+
+	return settings, nil
+}
+
+// Get returns a specific setting
+func (c *crdSettingsClient) Get(ctx context.Context, name string) (*Setting, error) {
+	debugLog("Getting Longhorn setting %s via CRD", name)
+
+	unstructuredSetting, err := c.crdClient.dynamicClient.Resource(settingGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setting %s: %w", name, err)
+	}
+
+	return unstructuredToSetting(unstructuredSetting), nil
+}
+
+// Update changes the value of a setting
+func (c *crdSettingsClient) Update(
+	ctx context.Context,
+	name string,
+	value string,
+) (*Setting, error) {
+	debugLog("Updating Longhorn setting %s via CRD", name)
+
+	mutate := func(current *unstructured.Unstructured) error {
+		// The setting value is a top-level field, not part of a spec
+		if err := unstructured.SetNestedField(current.Object, value, "value"); err != nil {
+			return fmt.Errorf("failed to set value: %w", err)
+		}
+		return nil
+	}
+
+	updated, err := c.crdClient.updateWithRetry(ctx, settingGVR, name, mutate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update setting %s: %w", name, err)
+	}
+
+	return unstructuredToSetting(updated), nil
+}
+
+// Helper function to convert unstructured to Setting
+func unstructuredToSetting(u *unstructured.Unstructured) *Setting {
+	setting := &Setting{
+		Name: u.GetName(),
+	}
+	if v, ok := u.Object["value"].(string); ok {
+		setting.Value = v
+	}
+	return setting
+}
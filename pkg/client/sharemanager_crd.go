@@ -0,0 +1,95 @@
+// pkg/client/sharemanager_crd.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// shareManagerPodPrefix prefixes the name of the pod running a share manager
+const shareManagerPodPrefix = "share-manager-"
+
+// shareManagerClient implementation for CRDs
+type crdShareManagerClient struct {
+	crdClient *LonghornCRDClient
+}
+
+// List returns all share managers
+func (c *crdShareManagerClient) List(ctx context.Context) ([]ShareManager, error) {
+	debugLog("Listing Longhorn share managers via CRD")
+
+	list, err := c.crdClient.dynamicClient.Resource(shareManagerGVR).
+		Namespace(c.crdClient.namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share managers: %w", err)
+	}
+
+	managers := make([]ShareManager, 0, len(list.Items))
+	for _, item := range list.Items {
+		managers = append(managers, *unstructuredToShareManager(&item))
+	}
+
+	return managers, nil
+}
+
+// Get returns a specific share manager
+func (c *crdShareManagerClient) Get(ctx context.Context, name string) (*ShareManager, error) {
+	debugLog("Getting Longhorn share manager %s via CRD", name)
+
+	unstructuredManager, err := c.crdClient.dynamicClient.Resource(shareManagerGVR).
+		Namespace(c.crdClient.namespace).
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share manager %s: %w", name, err)
+	}
+
+	return unstructuredToShareManager(unstructuredManager), nil
+}
+
+// Restart deletes the pod of a share manager. Longhorn recreates the pod and
+// the NFS clients of the volume reconnect to the new server.
+func (c *crdShareManagerClient) Restart(ctx context.Context, name string) error {
+	debugLog("Restarting Longhorn share manager %s via CRD", name)
+
+	podName := shareManagerPodPrefix + name
+	err := c.crdClient.dynamicClient.Resource(podGVR).
+		Namespace(c.crdClient.namespace).
+		Delete(ctx, podName, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete share manager pod %s: %w", podName, err)
+	}
+
+	return nil
+}
+
+// Helper function to convert unstructured to ShareManager
+func unstructuredToShareManager(u *unstructured.Unstructured) *ShareManager {
+	manager := &ShareManager{
+		Name:   u.GetName(),
+		Volume: u.GetName(),
+	}
+
+	if spec, found, err := unstructured.NestedMap(u.Object, "spec"); err == nil && found {
+		if v, ok := spec["image"].(string); ok {
+			manager.Image = v
+		}
+	}
+
+	if status, found, err := unstructured.NestedMap(u.Object, "status"); err == nil && found {
+		if v, ok := status["ownerID"].(string); ok {
+			manager.NodeID = v
+		}
+		if v, ok := status["state"].(string); ok {
+			manager.State = v
+		}
+		if v, ok := status["endpoint"].(string); ok {
+			manager.Endpoint = v
+		}
+	}
+
+	return manager
+}
@@ -0,0 +1,128 @@
+// pkg/client/storageclass.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultStorageClassAnnotation marks the default StorageClass of a cluster
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// ListStorageClasses returns the StorageClasses provisioned by the Longhorn
+// CSI driver
+func (c *Client) ListStorageClasses(ctx context.Context) ([]StorageClass, error) {
+	if c.crdClient == nil || c.crdClient.kubeClient == nil {
+		return nil, ErrKubernetesRequired
+	}
+
+	debugLog("Listing Longhorn storage classes via Kubernetes API")
+
+	list, err := c.crdClient.kubeClient.StorageV1().StorageClasses().
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage classes: %w", err)
+	}
+
+	classes := make([]StorageClass, 0, len(list.Items))
+	for i := range list.Items {
+		if list.Items[i].Provisioner != LonghornCSIDriver {
+			continue
+		}
+		classes = append(classes, *convertStorageClass(&list.Items[i]))
+	}
+
+	return classes, nil
+}
+
+// GetStorageClass returns a specific StorageClass, whatever its provisioner
+func (c *Client) GetStorageClass(ctx context.Context, name string) (*StorageClass, error) {
+	if c.crdClient == nil || c.crdClient.kubeClient == nil {
+		return nil, ErrKubernetesRequired
+	}
+
+	debugLog("Getting storage class %s via Kubernetes API", name)
+
+	sc, err := c.crdClient.kubeClient.StorageV1().StorageClasses().
+		Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage class %s: %w", name, err)
+	}
+
+	return convertStorageClass(sc), nil
+}
+
+// CreateStorageClass creates a StorageClass as returned by NewStorageClass
+func (c *Client) CreateStorageClass(
+	ctx context.Context,
+	sc *storagev1.StorageClass,
+) (*StorageClass, error) {
+	if c.crdClient == nil || c.crdClient.kubeClient == nil {
+		return nil, ErrKubernetesRequired
+	}
+
+	debugLog("Creating storage class %s via Kubernetes API", sc.Name)
+
+	created, err := c.crdClient.kubeClient.StorageV1().StorageClasses().
+		Create(ctx, sc, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage class %s: %w", sc.Name, err)
+	}
+
+	return convertStorageClass(created), nil
+}
+
+// NewStorageClass returns a Longhorn StorageClass for the given settings.
+// Empty policies and binding modes are left to the Kubernetes defaults.
+func NewStorageClass(class *StorageClass) *storagev1.StorageClass {
+	sc := &storagev1.StorageClass{
+		TypeMeta: metav1.TypeMeta{APIVersion: "storage.k8s.io/v1", Kind: "StorageClass"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: class.Name,
+		},
+		Provisioner:          LonghornCSIDriver,
+		Parameters:           class.Parameters,
+		AllowVolumeExpansion: &class.AllowVolumeExpansion,
+	}
+
+	if class.IsDefault {
+		sc.Annotations = map[string]string{defaultStorageClassAnnotation: "true"}
+	}
+	if class.ReclaimPolicy != "" {
+		policy := corev1.PersistentVolumeReclaimPolicy(class.ReclaimPolicy)
+		sc.ReclaimPolicy = &policy
+	}
+	if class.VolumeBindingMode != "" {
+		mode := storagev1.VolumeBindingMode(class.VolumeBindingMode)
+		sc.VolumeBindingMode = &mode
+	}
+
+	return sc
+}
+
+// Helper function to convert a Kubernetes StorageClass
+func convertStorageClass(sc *storagev1.StorageClass) *StorageClass {
+	class := &StorageClass{
+		Name:        sc.Name,
+		Provisioner: sc.Provisioner,
+		IsDefault:   sc.Annotations[defaultStorageClassAnnotation] == "true",
+		Parameters:  sc.Parameters,
+		Created:     sc.CreationTimestamp.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if sc.ReclaimPolicy != nil {
+		class.ReclaimPolicy = string(*sc.ReclaimPolicy)
+	}
+	if sc.VolumeBindingMode != nil {
+		class.VolumeBindingMode = string(*sc.VolumeBindingMode)
+	}
+	if sc.AllowVolumeExpansion != nil {
+		class.AllowVolumeExpansion = *sc.AllowVolumeExpansion
+	}
+
+	return class
+}
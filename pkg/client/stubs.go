@@ -6,127 +6,161 @@ import (
 	"fmt"
 )
 
-// volumeClient implements VolumeInterface
-type volumeClient struct {
+// settingsClient implements SettingsInterface
+type settingsClient struct {
 	client *Client
 }
 
-func (v *volumeClient) List() ([]Volume, error) {
+func (s *settingsClient) List(ctx context.Context) (map[string]Setting, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (v *volumeClient) Get(name string) (*Volume, error) {
+func (s *settingsClient) Get(ctx context.Context, name string) (*Setting, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (v *volumeClient) Create(volume *VolumeCreateInput) (*Volume, error) {
+func (s *settingsClient) Update(ctx context.Context, name string, value string) (*Setting, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (v *volumeClient) Delete(name string) error {
+// backupClient implements BackupInterface
+type backupClient struct {
+	client *Client
+}
+
+func (b *backupClient) List(ctx context.Context, volumeName string) ([]Backup, error) {
 	// TODO: Implement
-	return fmt.Errorf("not implemented")
+	return nil, fmt.Errorf("not implemented")
 }
 
-func (v *volumeClient) Update(name string, volume *VolumeUpdateInput) (*Volume, error) {
+func (b *backupClient) Get(ctx context.Context, backupName string) (*Backup, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (v *volumeClient) Attach(name string, input *VolumeAttachInput) (*Volume, error) {
+func (b *backupClient) Create(
+	ctx context.Context,
+	volumeName string,
+	input *BackupCreateInput,
+) (*Backup, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (v *volumeClient) Detach(name string) error {
+func (b *backupClient) Delete(ctx context.Context, backupName string) error {
 	// TODO: Implement
 	return fmt.Errorf("not implemented")
 }
 
-// settingsClient implements SettingsInterface
-type settingsClient struct {
+func (b *backupClient) GetTarget(ctx context.Context) (*BackupTarget, error) {
+	// TODO: Implement
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (b *backupClient) SetTarget(ctx context.Context, target *BackupTarget) error {
+	// TODO: Implement
+	return fmt.Errorf("not implemented")
+}
+
+// engineImageClient implements EngineImageInterface
+type engineImageClient struct {
 	client *Client
 }
 
-func (s *settingsClient) List() (map[string]Setting, error) {
+func (e *engineImageClient) List(ctx context.Context) ([]EngineImage, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (s *settingsClient) Get(name string) (*Setting, error) {
+func (e *engineImageClient) Get(ctx context.Context, name string) (*EngineImage, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (s *settingsClient) Update(name string, value string) (*Setting, error) {
+func (e *engineImageClient) Delete(ctx context.Context, name string) error {
 	// TODO: Implement
-	return nil, fmt.Errorf("not implemented")
+	return fmt.Errorf("not implemented")
 }
 
-// backupClient implements BackupInterface
-type backupClient struct {
+// instanceManagerClient implements InstanceManagerInterface
+type instanceManagerClient struct {
 	client *Client
 }
 
-func (b *backupClient) List(volumeName string) ([]Backup, error) {
+func (i *instanceManagerClient) List(ctx context.Context) ([]InstanceManager, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (b *backupClient) Get(backupName string) (*Backup, error) {
+func (i *instanceManagerClient) Get(ctx context.Context, name string) (*InstanceManager, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (b *backupClient) Create(volumeName string, input *BackupCreateInput) (*Backup, error) {
-	// TODO: Implement
-	return nil, fmt.Errorf("not implemented")
+// orphanClient implements OrphanInterface
+type orphanClient struct {
+	client *Client
 }
 
-func (b *backupClient) Delete(backupName string) error {
+func (o *orphanClient) List(ctx context.Context) ([]Orphan, error) {
 	// TODO: Implement
-	return fmt.Errorf("not implemented")
+	return nil, fmt.Errorf("not implemented")
 }
 
-func (b *backupClient) GetTarget() (*BackupTarget, error) {
+func (o *orphanClient) Get(ctx context.Context, name string) (*Orphan, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (b *backupClient) SetTarget(target *BackupTarget) error {
+func (o *orphanClient) Delete(ctx context.Context, name string) error {
 	// TODO: Implement
 	return fmt.Errorf("not implemented")
 }
 
-// engineImageClient implements EngineImageInterface
-type engineImageClient struct {
+// shareManagerClient implements ShareManagerInterface
+type shareManagerClient struct {
 	client *Client
 }
 
-func (e *engineImageClient) List() ([]EngineImage, error) {
+func (s *shareManagerClient) List(ctx context.Context) ([]ShareManager, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (e *engineImageClient) Get(name string) (*EngineImage, error) {
+func (s *shareManagerClient) Get(ctx context.Context, name string) (*ShareManager, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (e *engineImageClient) Delete(name string) error {
+func (s *shareManagerClient) Restart(ctx context.Context, name string) error {
 	// TODO: Implement
 	return fmt.Errorf("not implemented")
 }
 
+// engineClient implements EngineInterface
+type engineClient struct {
+	client *Client
+}
+
+func (e *engineClient) List(ctx context.Context) ([]Engine, error) {
+	// TODO: Implement
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (e *engineClient) Get(ctx context.Context, name string) (*Engine, error) {
+	// TODO: Implement
+	return nil, fmt.Errorf("not implemented")
+}
+
 // eventClient implements EventInterface
 type eventClient struct {
 	client *Client
 }
 
-func (e *eventClient) List(opts EventListOptions) ([]Event, error) {
+func (e *eventClient) List(ctx context.Context, opts EventListOptions) ([]Event, error) {
 	// TODO: Implement
 	return nil, fmt.Errorf("not implemented")
 }
@@ -1,7 +1,10 @@
 // pkg/client/types.go
 package client
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Node represents a Longhorn node
 type Node struct {
@@ -67,6 +70,12 @@ type DiskUpdate struct {
 	Tags              []string `json:"tags,omitempty"`
 }
 
+// DiskRemoveOptions controls the removal of a disk from a node
+type DiskRemoveOptions struct {
+	// Force removes the disk even if replicas are still scheduled on it
+	Force bool
+}
+
 // Volume represents a Longhorn volume
 // In pkg/client/types.go, update the Volume struct to include ActualSize:
 
@@ -91,6 +100,27 @@ type Volume struct {
 	Conditions       map[string]Status `json:"conditions"`
 	Replicas         []Replica         `json:"replicas"`
 	Labels           map[string]string `json:"labels,omitempty"`
+	KubernetesStatus KubernetesStatus  `json:"kubernetesStatus"`
+}
+
+// KubernetesStatus is the Kubernetes view of a volume: the PV and PVC bound
+// to it and the pods using it
+type KubernetesStatus struct {
+	PVName          string           `json:"pvName"`
+	PVStatus        string           `json:"pvStatus"`
+	Namespace       string           `json:"namespace"` // namespace of the PVC
+	PVCName         string           `json:"pvcName"`
+	LastPVCRefAt    string           `json:"lastPVCRefAt,omitempty"`
+	LastPodRefAt    string           `json:"lastPodRefAt,omitempty"`
+	WorkloadsStatus []WorkloadStatus `json:"workloadsStatus,omitempty"`
+}
+
+// WorkloadStatus represents a pod using a volume and the workload owning it
+type WorkloadStatus struct {
+	PodName      string `json:"podName"`
+	PodStatus    string `json:"podStatus"`
+	WorkloadName string `json:"workloadName,omitempty"`
+	WorkloadType string `json:"workloadType,omitempty"`
 }
 
 // VolumeCreateInput represents volume creation parameters
@@ -133,6 +163,29 @@ type Controller struct {
 	InstanceManagerName string `json:"instanceManagerName"`
 }
 
+// Engine represents a volume engine (controller)
+type Engine struct {
+	Name              string                   `json:"name"`
+	VolumeName        string                   `json:"volumeName"`
+	NodeID            string                   `json:"nodeID"`
+	CurrentState      string                   `json:"currentState"`
+	Image             string                   `json:"image"`
+	Endpoint          string                   `json:"endpoint"`
+	InstanceManager   string                   `json:"instanceManager"`
+	ReplicaAddressMap map[string]string        `json:"replicaAddressMap"` // replica name -> address
+	ReplicaModeMap    map[string]string        `json:"replicaModeMap"`    // replica name -> mode (RW/WO/ERR)
+	RebuildStatus     map[string]RebuildStatus `json:"rebuildStatus"`     // replica address -> status
+}
+
+// RebuildStatus represents the rebuild progress of a replica
+type RebuildStatus struct {
+	IsRebuilding       bool   `json:"isRebuilding"`
+	Progress           int    `json:"progress"`
+	State              string `json:"state"`
+	Error              string `json:"error"`
+	FromReplicaAddress string `json:"fromReplicaAddress"`
+}
+
 // Replica represents a volume replica
 // Replica represents a volume replica
 type Replica struct {
@@ -221,6 +274,65 @@ type EngineImage struct {
 	Conditions        map[string]Status `json:"conditions"`
 }
 
+// InstanceManager represents a Longhorn instance manager, the pod that runs
+// the engine and replica processes on a node
+type InstanceManager struct {
+	Name         string     `json:"name"`
+	NodeID       string     `json:"nodeID"`
+	Type         string     `json:"type"`       // engine, replica or aio
+	DataEngine   string     `json:"dataEngine"` // v1 or v2
+	Image        string     `json:"image"`
+	CurrentState string     `json:"currentState"`
+	IP           string     `json:"ip"`
+	Instances    []Instance `json:"instances"`
+}
+
+// Instance represents an engine or replica process in an instance manager
+type Instance struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"` // engine or replica
+	State    string `json:"state"`
+	Port     int    `json:"port"`
+	ErrorMsg string `json:"errorMsg,omitempty"`
+}
+
+// Orphan represents data on a disk that Longhorn no longer tracks, such as
+// the directory of a replica that was deleted while its node was down
+type Orphan struct {
+	Name       string            `json:"name"`
+	NodeID     string            `json:"nodeID"`
+	Type       string            `json:"type"`
+	DataName   string            `json:"dataName"`
+	DiskName   string            `json:"diskName"`
+	DiskUUID   string            `json:"diskUUID"`
+	DiskPath   string            `json:"diskPath"`
+	Conditions map[string]Status `json:"conditions"`
+}
+
+// ShareManager represents the NFS server that exports an RWX volume. It is
+// named after the volume it serves.
+type ShareManager struct {
+	Name     string `json:"name"`
+	Volume   string `json:"volume"`
+	NodeID   string `json:"nodeID"`
+	State    string `json:"state"`
+	Endpoint string `json:"endpoint"`
+	Image    string `json:"image"`
+}
+
+// StorageClass represents a Kubernetes StorageClass provisioning Longhorn
+// volumes
+type StorageClass struct {
+	Name                 string            `json:"name"`
+	Provisioner          string            `json:"provisioner"`
+	ReclaimPolicy        string            `json:"reclaimPolicy"`
+	VolumeBindingMode    string            `json:"volumeBindingMode"`
+	AllowVolumeExpansion bool              `json:"allowVolumeExpansion"`
+	IsDefault            bool              `json:"isDefault"`
+	Parameters           map[string]string `json:"parameters"`
+	Created              string            `json:"created"`
+}
+
 // RecurringJob represents a recurring job configuration
 type RecurringJob struct {
 	Name        string            `json:"name"`
@@ -247,6 +359,7 @@ type EventListOptions struct {
 	ResourceType string
 	ResourceName string
 	EventType    string
+	Since        time.Time
 }
 
 // ErrorResponse from Longhorn API
@@ -256,3 +369,11 @@ type ErrorResponse struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
+
+// Error implements the error interface
+func (e *ErrorResponse) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s (%s, status %d)", e.Message, e.Code, e.Status)
+	}
+	return fmt.Sprintf("%s (status %d)", e.Message, e.Status)
+}
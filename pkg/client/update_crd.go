@@ -0,0 +1,81 @@
+// pkg/client/update_crd.go
+package client
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultConflictRetries is how often an update is retried after it
+// conflicted with a concurrent change
+const DefaultConflictRetries = 5
+
+// SetConflictRetries sets how often updates are retried after conflicting
+// with a concurrent change. Zero disables retries.
+func (c *Client) SetConflictRetries(retries int) {
+	if c.crdClient != nil && retries >= 0 {
+		c.crdClient.conflictRetries = retries
+	}
+}
+
+// SetRetryLogger sets a function that is told about every retried update,
+// e.g. to report retries in verbose output
+func (c *Client) SetRetryLogger(logger func(format string, args ...interface{})) {
+	if c.crdClient != nil {
+		c.crdClient.retryLogger = logger
+	}
+}
+
+// updateWithRetry reads an object, applies mutate and writes it back. If the
+// write conflicts with a concurrent change, e.g. by another lhcli run or a
+// Longhorn controller, the object is read again and mutate is reapplied, so
+// no change is lost. Errors returned by mutate end the update.
+func (c *LonghornCRDClient) updateWithRetry(
+	ctx context.Context,
+	gvr schema.GroupVersionResource,
+	name string,
+	mutate func(obj *unstructured.Unstructured) error,
+) (*unstructured.Unstructured, error) {
+	resource := c.dynamicClient.Resource(gvr).Namespace(c.namespace)
+
+	backoff := retry.DefaultRetry
+	backoff.Steps = c.conflictRetries + 1
+
+	attempt := 0
+	var updated *unstructured.Unstructured
+	err := retry.RetryOnConflict(backoff, func() error {
+		attempt++
+		if attempt > 1 {
+			c.logRetry("Update of %s/%s conflicted with a concurrent change, retrying (%d/%d)",
+				gvr.Resource, name, attempt-1, c.conflictRetries)
+		}
+
+		current, err := resource.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := mutate(current); err != nil {
+			return err
+		}
+
+		updated, err = resource.Update(ctx, current, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// logRetry reports a retry to the retry logger and the debug log
+func (c *LonghornCRDClient) logRetry(format string, args ...interface{}) {
+	debugLog(format, args...)
+	if c.retryLogger != nil {
+		c.retryLogger(format, args...)
+	}
+}
@@ -0,0 +1,184 @@
+// pkg/client/update_crd_test.go
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeCRDClient creates a CRD client over a fake dynamic client holding
+// the given objects
+func newFakeCRDClient(objects ...runtime.Object) (*LonghornCRDClient, *fake.FakeDynamicClient) {
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{nodeGVR: "NodeList"},
+		objects...,
+	)
+	return &LonghornCRDClient{
+		dynamicClient:   dynamicClient,
+		watchClient:     dynamicClient,
+		namespace:       "longhorn-system",
+		conflictRetries: DefaultConflictRetries,
+	}, dynamicClient
+}
+
+// testNode returns a node with one disk and the given replicas scheduled on it
+func testNode(replicas map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "longhorn.io/v1beta2",
+		"kind":       "Node",
+		"metadata": map[string]interface{}{
+			"name":      "node-1",
+			"namespace": "longhorn-system",
+		},
+		"spec": map[string]interface{}{
+			"disks": map[string]interface{}{
+				"disk-1": map[string]interface{}{"path": "/data", "allowScheduling": true},
+			},
+		},
+		"status": map[string]interface{}{
+			"diskStatus": map[string]interface{}{
+				"disk-1": map[string]interface{}{"scheduledReplica": replicas},
+			},
+		},
+	}}
+}
+
+func TestUpdateWithRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		retries     int
+		conflicts   int
+		wantErr     error
+		wantUpdates int
+	}{
+		{name: "no conflict", retries: 5, wantUpdates: 1},
+		{name: "conflicts within the limit", retries: 5, conflicts: 3, wantUpdates: 4},
+		{name: "conflicts up to the limit", retries: 2, conflicts: 2, wantUpdates: 3},
+		{name: "too many conflicts", retries: 2, conflicts: 3, wantErr: ErrConflict, wantUpdates: 3},
+		{name: "retries disabled", retries: 0, conflicts: 1, wantErr: ErrConflict, wantUpdates: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crdClient, dynamicClient := newFakeCRDClient(testNode(map[string]interface{}{}))
+			crdClient.conflictRetries = tt.retries
+
+			var retries int
+			crdClient.retryLogger = func(string, ...interface{}) { retries++ }
+
+			// Every conflicting update is caused by another writer changing
+			// the node, which the retried update must not undo
+			updates := 0
+			dynamicClient.PrependReactor("update", "nodes",
+				func(action k8stesting.Action) (bool, runtime.Object, error) {
+					updates++
+					if updates > tt.conflicts {
+						return false, nil, nil
+					}
+
+					node, err := dynamicClient.Tracker().Get(nodeGVR, "longhorn-system", "node-1")
+					if err != nil {
+						return true, nil, err
+					}
+					other := node.(*unstructured.Unstructured).DeepCopy()
+					labels := other.GetLabels()
+					if labels == nil {
+						labels = map[string]string{}
+					}
+					labels["writer"] = "other"
+					other.SetLabels(labels)
+					if err := dynamicClient.Tracker().Update(nodeGVR, other, "longhorn-system"); err != nil {
+						return true, nil, err
+					}
+
+					return true, nil, apierrors.NewConflict(nodeGVR.GroupResource(), "node-1",
+						errors.New("the object has been modified"))
+				})
+
+			reads := 0
+			updated, err := crdClient.updateWithRetry(context.Background(), nodeGVR, "node-1",
+				func(obj *unstructured.Unstructured) error {
+					reads++
+					return unstructured.SetNestedField(obj.Object, true, "spec", "evictionRequested")
+				})
+
+			if updates != tt.wantUpdates {
+				t.Errorf("updates = %d, want %d", updates, tt.wantUpdates)
+			}
+			if reads != tt.wantUpdates {
+				t.Errorf("node read %d times, want once per update (%d)", reads, tt.wantUpdates)
+			}
+			if retries != tt.wantUpdates-1 {
+				t.Errorf("logged %d retries, want %d", retries, tt.wantUpdates-1)
+			}
+
+			if tt.wantErr != nil {
+				if KindOf(err) != tt.wantErr {
+					t.Fatalf("updateWithRetry() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("updateWithRetry() error = %v", err)
+			}
+
+			stored, err := dynamicClient.Resource(nodeGVR).Namespace("longhorn-system").
+				Get(context.Background(), "node-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, node := range []*unstructured.Unstructured{updated, stored} {
+				evict, _, _ := unstructured.NestedBool(node.Object, "spec", "evictionRequested")
+				if !evict {
+					t.Error("update was not applied")
+				}
+				if tt.conflicts > 0 && node.GetLabels()["writer"] != "other" {
+					t.Error("update overwrote the concurrent change")
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateWithRetryMutateError(t *testing.T) {
+	crdClient, dynamicClient := newFakeCRDClient(testNode(map[string]interface{}{}))
+
+	updates := 0
+	dynamicClient.PrependReactor("update", "nodes",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updates++
+			return false, nil, nil
+		})
+
+	mutateErr := errors.New("disk not found")
+	_, err := crdClient.updateWithRetry(context.Background(), nodeGVR, "node-1",
+		func(obj *unstructured.Unstructured) error { return mutateErr })
+
+	if !errors.Is(err, mutateErr) {
+		t.Errorf("updateWithRetry() error = %v, want %v", err, mutateErr)
+	}
+	if updates != 0 {
+		t.Errorf("updates = %d, want 0", updates)
+	}
+}
+
+func TestUpdateWithRetryNotFound(t *testing.T) {
+	crdClient, _ := newFakeCRDClient()
+
+	_, err := crdClient.updateWithRetry(context.Background(), nodeGVR, "node-1",
+		func(obj *unstructured.Unstructured) error { return nil })
+
+	if KindOf(err) != ErrNotFound {
+		t.Errorf("updateWithRetry() error = %v, want NotFound", err)
+	}
+}
@@ -0,0 +1,301 @@
+// pkg/client/volume.go
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pascal71/lhcli/internal/validation"
+)
+
+// volumeClient implements VolumeInterface using HTTP API
+type volumeClient struct {
+	client *Client
+}
+
+// apiVolume is a volume as returned by the Longhorn manager API, which
+// reports replicas and the engine in its own format
+type apiVolume struct {
+	Volume
+	Replicas    []apiReplica    `json:"replicas"`
+	Controllers []apiController `json:"controllers"`
+}
+
+// apiReplica is a replica of an apiVolume
+type apiReplica struct {
+	Name                string `json:"name"`
+	HostID              string `json:"hostId"`
+	DiskID              string `json:"diskID"`
+	DiskPath            string `json:"diskPath"`
+	DataPath            string `json:"dataPath"`
+	Mode                string `json:"mode"`
+	FailedAt            string `json:"failedAt"`
+	Running             bool   `json:"running"`
+	Image               string `json:"image"`
+	CurrentImage        string `json:"currentImage"`
+	InstanceManagerName string `json:"instanceManagerName"`
+}
+
+// apiController is the engine of an apiVolume
+type apiController struct {
+	ActualSize string `json:"actualSize"`
+}
+
+// toVolume converts an apiVolume
+func (v *apiVolume) toVolume() *Volume {
+	volume := v.Volume
+
+	volume.Replicas = make([]Replica, 0, len(v.Replicas))
+	for _, r := range v.Replicas {
+		volume.Replicas = append(volume.Replicas, Replica{
+			Name:            r.Name,
+			NodeID:          r.HostID,
+			DiskID:          r.DiskID,
+			VolumeName:      v.Name,
+			DiskPath:        r.DiskPath,
+			DataPath:        r.DataPath,
+			Mode:            r.Mode,
+			FailedAt:        r.FailedAt,
+			Running:         r.Running,
+			SpecSize:        v.Size,
+			InstanceManager: r.InstanceManagerName,
+			Image:           r.Image,
+			CurrentImage:    r.CurrentImage,
+		})
+	}
+
+	if len(v.Controllers) > 0 {
+		if size, err := strconv.ParseInt(v.Controllers[0].ActualSize, 10, 64); err == nil {
+			volume.ActualSize = size
+		}
+	}
+
+	return &volume
+}
+
+// List returns all volumes
+func (c *volumeClient) List(ctx context.Context) ([]Volume, error) {
+	debugLog("Listing volumes via HTTP")
+
+	resp, err := c.client.doRequest(ctx, "GET", "/volumes", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp)
+	}
+
+	var result struct {
+		Data []apiVolume `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	volumes := make([]Volume, 0, len(result.Data))
+	for i := range result.Data {
+		volumes = append(volumes, *result.Data[i].toVolume())
+	}
+
+	return volumes, nil
+}
+
+// Get returns a specific volume
+func (c *volumeClient) Get(ctx context.Context, name string) (*Volume, error) {
+	debugLog("Getting volume: %s", name)
+
+	resp, err := c.client.doRequest(ctx, "GET", volumePath(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("volume %s %w", name, ErrNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResponse(resp)
+	}
+
+	return decodeVolume(resp)
+}
+
+// Create creates a new volume
+func (c *volumeClient) Create(ctx context.Context, input *VolumeCreateInput) (*Volume, error) {
+	debugLog("Creating volume: %s", input.Name)
+
+	resp, err := c.client.doRequest(ctx, "POST", "/volumes", input)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to create volume %s: %w", input.Name, errorFromResponse(resp))
+	}
+
+	return decodeVolume(resp)
+}
+
+// Delete deletes a volume
+func (c *volumeClient) Delete(ctx context.Context, name string) error {
+	debugLog("Deleting volume: %s", name)
+
+	resp, err := c.client.doRequest(ctx, "DELETE", volumePath(name), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("volume %s %w", name, ErrNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete volume %s: %w", name, errorFromResponse(resp))
+	}
+
+	return nil
+}
+
+// Update updates a volume. The Longhorn API changes each setting with its
+// own action, so the changes are applied one after another. All changes are
+// validated before the first is applied; if an action still fails, the error
+// names the actions that were already applied.
+func (c *volumeClient) Update(
+	ctx context.Context,
+	name string,
+	update *VolumeUpdateInput,
+) (*Volume, error) {
+	debugLog("Updating volume: %s", name)
+
+	if err := validateVolumeUpdate(update); err != nil {
+		return nil, err
+	}
+
+	var applied []string
+	apply := func(action string, input map[string]interface{}) error {
+		if _, err := c.action(ctx, name, action, input); err != nil {
+			if len(applied) > 0 {
+				return fmt.Errorf("%w (already applied: %s)", err, strings.Join(applied, ", "))
+			}
+			return err
+		}
+		applied = append(applied, action)
+		return nil
+	}
+
+	if update.NumberOfReplicas != nil {
+		input := map[string]interface{}{"replicaCount": *update.NumberOfReplicas}
+		if err := apply("updateReplicaCount", input); err != nil {
+			return nil, err
+		}
+	}
+	if update.DataLocality != "" {
+		input := map[string]interface{}{"dataLocality": update.DataLocality}
+		if err := apply("updateDataLocality", input); err != nil {
+			return nil, err
+		}
+	}
+	if update.AccessMode != "" {
+		input := map[string]interface{}{"accessMode": update.AccessMode}
+		if err := apply("updateAccessMode", input); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.Get(ctx, name)
+}
+
+// validateVolumeUpdate checks the changes of a volume update
+func validateVolumeUpdate(update *VolumeUpdateInput) error {
+	if len(update.Labels) > 0 {
+		return fmt.Errorf("volume labels cannot be changed through the Longhorn API")
+	}
+	if update.NumberOfReplicas != nil {
+		if err := validation.ValidateReplicaCount(*update.NumberOfReplicas); err != nil {
+			return err
+		}
+	}
+	if update.DataLocality != "" {
+		if err := validation.ValidateDataLocality(update.DataLocality); err != nil {
+			return fmt.Errorf("invalid data locality: %w", err)
+		}
+	}
+	if update.AccessMode != "" {
+		if err := validation.ValidateAccessMode(update.AccessMode); err != nil {
+			return fmt.Errorf("invalid access mode: %w", err)
+		}
+	}
+	return nil
+}
+
+// Attach attaches a volume to a node
+func (c *volumeClient) Attach(
+	ctx context.Context,
+	name string,
+	input *VolumeAttachInput,
+) (*Volume, error) {
+	debugLog("Attaching volume %s to node %s", name, input.HostID)
+
+	return c.action(ctx, name, "attach", input)
+}
+
+// Detach detaches a volume
+func (c *volumeClient) Detach(ctx context.Context, name string) error {
+	debugLog("Detaching volume: %s", name)
+
+	_, err := c.action(ctx, name, "detach", map[string]interface{}{})
+	return err
+}
+
+// Watch is not supported by the HTTP API
+func (c *volumeClient) Watch(ctx context.Context, callback func([]Volume)) error {
+	return ErrWatchNotSupported
+}
+
+// action runs a volume action and returns the updated volume
+func (c *volumeClient) action(
+	ctx context.Context,
+	name, action string,
+	input interface{},
+) (*Volume, error) {
+	path := fmt.Sprintf("%s?action=%s", volumePath(name), action)
+	resp, err := c.client.doRequest(ctx, "POST", path, input)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("volume %s %w", name, ErrNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to %s volume %s: %w", action, name, errorFromResponse(resp))
+	}
+
+	return decodeVolume(resp)
+}
+
+// decodeVolume decodes a volume response
+func decodeVolume(resp *http.Response) (*Volume, error) {
+	var volume apiVolume
+	if err := json.NewDecoder(resp.Body).Decode(&volume); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return volume.toVolume(), nil
+}
+
+// volumePath returns the API path of a volume
+func volumePath(name string) string {
+	return "/volumes/" + url.PathEscape(name)
+}
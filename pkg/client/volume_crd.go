@@ -26,12 +26,12 @@ func (c *Client) VolumesCRD() VolumeInterface {
 }
 
 // List returns all Longhorn volumes with their replicas
-func (c *crdVolumeClient) List() ([]Volume, error) {
+func (c *crdVolumeClient) List(ctx context.Context) ([]Volume, error) {
 	debugLog("Listing Longhorn volumes via CRD")
 
 	list, err := c.crdClient.dynamicClient.Resource(volumeGVR).
 		Namespace(c.crdClient.namespace).
-		List(context.TODO(), metav1.ListOptions{})
+		List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list volumes: %w", err)
 	}
@@ -50,7 +50,7 @@ func (c *crdVolumeClient) List() ([]Volume, error) {
 	debugLog("Fetching replicas for volumes")
 	replicaList, err := c.crdClient.dynamicClient.Resource(replicaGVR).
 		Namespace(c.crdClient.namespace).
-		List(context.TODO(), metav1.ListOptions{})
+		List(ctx, metav1.ListOptions{})
 	if err != nil {
 		debugLog("Warning: failed to list replicas: %v", err)
 		// Don't fail completely if we can't get replicas
@@ -85,12 +85,12 @@ func (c *crdVolumeClient) List() ([]Volume, error) {
 }
 
 // Get returns a specific volume with its replicas
-func (c *crdVolumeClient) Get(name string) (*Volume, error) {
+func (c *crdVolumeClient) Get(ctx context.Context, name string) (*Volume, error) {
 	debugLog("Getting Longhorn volume %s via CRD", name)
 
 	unstructuredVolume, err := c.crdClient.dynamicClient.Resource(volumeGVR).
 		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), name, metav1.GetOptions{})
+		Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get volume %s: %w", name, err)
 	}
@@ -104,7 +104,7 @@ func (c *crdVolumeClient) Get(name string) (*Volume, error) {
 	debugLog("Fetching replicas for volume %s", name)
 	replicaList, err := c.crdClient.dynamicClient.Resource(replicaGVR).
 		Namespace(c.crdClient.namespace).
-		List(context.TODO(), metav1.ListOptions{
+		List(ctx, metav1.ListOptions{
 			LabelSelector: fmt.Sprintf("longhornvolume=%s", name),
 		})
 	if err != nil {
@@ -129,7 +129,7 @@ func (c *crdVolumeClient) Get(name string) (*Volume, error) {
 }
 
 // Create creates a new volume
-func (c *crdVolumeClient) Create(input *VolumeCreateInput) (*Volume, error) {
+func (c *crdVolumeClient) Create(ctx context.Context, input *VolumeCreateInput) (*Volume, error) {
 	debugLog("Creating Longhorn volume %s via CRD", input.Name)
 
 	// Parse size string to bytes
@@ -199,7 +199,7 @@ func (c *crdVolumeClient) Create(input *VolumeCreateInput) (*Volume, error) {
 	// Create the volume
 	created, err := c.crdClient.dynamicClient.Resource(volumeGVR).
 		Namespace(c.crdClient.namespace).
-		Create(context.TODO(), u, metav1.CreateOptions{})
+		Create(ctx, u, metav1.CreateOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create volume: %w", err)
 	}
@@ -208,12 +208,12 @@ func (c *crdVolumeClient) Create(input *VolumeCreateInput) (*Volume, error) {
 }
 
 // Delete deletes a volume
-func (c *crdVolumeClient) Delete(name string) error {
+func (c *crdVolumeClient) Delete(ctx context.Context, name string) error {
 	debugLog("Deleting Longhorn volume %s via CRD", name)
 
 	err := c.crdClient.dynamicClient.Resource(volumeGVR).
 		Namespace(c.crdClient.namespace).
-		Delete(context.TODO(), name, metav1.DeleteOptions{})
+		Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete volume %s: %w", name, err)
 	}
@@ -222,48 +222,44 @@ func (c *crdVolumeClient) Delete(name string) error {
 }
 
 // Update updates a volume
-func (c *crdVolumeClient) Update(name string, update *VolumeUpdateInput) (*Volume, error) {
+func (c *crdVolumeClient) Update(
+	ctx context.Context,
+	name string,
+	update *VolumeUpdateInput,
+) (*Volume, error) {
 	debugLog("Updating Longhorn volume %s via CRD", name)
 
-	// Get current volume
-	current, err := c.crdClient.dynamicClient.Resource(volumeGVR).
-		Namespace(c.crdClient.namespace).
-		Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current volume: %w", err)
-	}
-
-	// Get the spec
-	spec, found, err := unstructured.NestedMap(current.Object, "spec")
-	if err != nil || !found {
-		spec = make(map[string]interface{})
-	}
+	mutate := func(current *unstructured.Unstructured) error {
+		// Get the spec
+		spec, found, err := unstructured.NestedMap(current.Object, "spec")
+		if err != nil || !found {
+			spec = make(map[string]interface{})
+		}
 
-	// Apply updates
-	if update.NumberOfReplicas != nil {
-		spec["numberOfReplicas"] = int64(*update.NumberOfReplicas)
-	}
-	if update.DataLocality != "" {
-		spec["dataLocality"] = update.DataLocality
-	}
-	if update.AccessMode != "" {
-		spec["accessMode"] = update.AccessMode
-	}
+		// Apply updates
+		if update.NumberOfReplicas != nil {
+			spec["numberOfReplicas"] = int64(*update.NumberOfReplicas)
+		}
+		if update.DataLocality != "" {
+			spec["dataLocality"] = update.DataLocality
+		}
+		if update.AccessMode != "" {
+			spec["accessMode"] = update.AccessMode
+		}
 
-	// Set the updated spec
-	if err := unstructured.SetNestedMap(current.Object, spec, "spec"); err != nil {
-		return nil, fmt.Errorf("failed to update spec: %w", err)
-	}
+		// Set the updated spec
+		if err := unstructured.SetNestedMap(current.Object, spec, "spec"); err != nil {
+			return fmt.Errorf("failed to update spec: %w", err)
+		}
 
-	// Update labels if provided
-	if len(update.Labels) > 0 {
-		current.SetLabels(update.Labels)
+		// Update labels if provided
+		if len(update.Labels) > 0 {
+			current.SetLabels(update.Labels)
+		}
+		return nil
 	}
 
-	// Update the volume
-	updated, err := c.crdClient.dynamicClient.Resource(volumeGVR).
-		Namespace(c.crdClient.namespace).
-		Update(context.TODO(), current, metav1.UpdateOptions{})
+	updated, err := c.crdClient.updateWithRetry(ctx, volumeGVR, name, mutate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update volume: %w", err)
 	}
@@ -272,7 +268,11 @@ func (c *crdVolumeClient) Update(name string, update *VolumeUpdateInput) (*Volum
 }
 
 // Attach attaches a volume to a node
-func (c *crdVolumeClient) Attach(name string, input *VolumeAttachInput) (*Volume, error) {
+func (c *crdVolumeClient) Attach(
+	ctx context.Context,
+	name string,
+	input *VolumeAttachInput,
+) (*Volume, error) {
 	debugLog("Attaching Longhorn volume %s to node %s via CRD", name, input.HostID)
 
 	// Create an engine CRD for attachment
@@ -314,7 +314,7 @@ func (c *crdVolumeClient) Attach(name string, input *VolumeAttachInput) (*Volume
 }
 
 // Detach detaches a volume
-func (c *crdVolumeClient) Detach(name string) error {
+func (c *crdVolumeClient) Detach(ctx context.Context, name string) error {
 	// For now, return an error as this needs more complex orchestration
 	return fmt.Errorf(
 		"detach operation requires more complex orchestration - use kubectl or Longhorn UI",
@@ -381,6 +381,10 @@ func unstructuredToVolume(u *unstructured.Unstructured) (*Volume, error) {
 			volume.ActualSize = int64(v)
 		}
 
+		if k8sStatus, ok := status["kubernetesStatus"].(map[string]interface{}); ok {
+			volume.KubernetesStatus = unstructuredToKubernetesStatus(k8sStatus)
+		}
+
 		// Get conditions
 		if conditions, ok := status["conditions"].([]interface{}); ok {
 			volume.Conditions = make(map[string]Status)
@@ -417,6 +421,55 @@ func unstructuredToVolume(u *unstructured.Unstructured) (*Volume, error) {
 	return volume, nil
 }
 
+// Helper function to convert a volume's kubernetesStatus
+func unstructuredToKubernetesStatus(data map[string]interface{}) KubernetesStatus {
+	status := KubernetesStatus{}
+
+	if v, ok := data["pvName"].(string); ok {
+		status.PVName = v
+	}
+	if v, ok := data["pvStatus"].(string); ok {
+		status.PVStatus = v
+	}
+	if v, ok := data["namespace"].(string); ok {
+		status.Namespace = v
+	}
+	if v, ok := data["pvcName"].(string); ok {
+		status.PVCName = v
+	}
+	if v, ok := data["lastPVCRefAt"].(string); ok {
+		status.LastPVCRefAt = v
+	}
+	if v, ok := data["lastPodRefAt"].(string); ok {
+		status.LastPodRefAt = v
+	}
+
+	if workloads, ok := data["workloadsStatus"].([]interface{}); ok {
+		for _, workloadData := range workloads {
+			workload, ok := workloadData.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ws := WorkloadStatus{}
+			if v, ok := workload["podName"].(string); ok {
+				ws.PodName = v
+			}
+			if v, ok := workload["podStatus"].(string); ok {
+				ws.PodStatus = v
+			}
+			if v, ok := workload["workloadName"].(string); ok {
+				ws.WorkloadName = v
+			}
+			if v, ok := workload["workloadType"].(string); ok {
+				ws.WorkloadType = v
+			}
+			status.WorkloadsStatus = append(status.WorkloadsStatus, ws)
+		}
+	}
+
+	return status
+}
+
 // Helper function to convert unstructured to Replica
 func unstructuredToReplica(u *unstructured.Unstructured) (*Replica, string, error) {
 	replica := &Replica{
@@ -0,0 +1,177 @@
+// pkg/client/volume_expose.go
+package client
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LonghornCSIDriver is the name of the Longhorn CSI driver
+	LonghornCSIDriver = "driver.longhorn.io"
+
+	// DefaultStaticStorageClass is the storage class Longhorn uses for PVs
+	// of existing volumes
+	DefaultStaticStorageClass = "longhorn-static"
+
+	// DefaultFSType is the filesystem used when none is given
+	DefaultFSType = "ext4"
+)
+
+// VolumeExposeInput represents the PV and PVC to create for a volume
+type VolumeExposeInput struct {
+	PVName       string `json:"pvName"` // defaults to the volume name
+	PVCName      string `json:"pvcName"`
+	PVCNamespace string `json:"pvcNamespace"`
+	StorageClass string `json:"storageClass"`
+	FSType       string `json:"fsType"`
+}
+
+// NewVolumePV returns the PersistentVolume that makes a Longhorn volume
+// available through the CSI driver. The PV is reserved for the PVC of the
+// input so no other claim can bind it.
+func NewVolumePV(volume *Volume, input *VolumeExposeInput) (*corev1.PersistentVolume, error) {
+	size, err := resource.ParseQuantity(volume.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size %q of volume %s: %w", volume.Size, volume.Name, err)
+	}
+
+	input = exposeDefaults(volume, input)
+	filesystem := corev1.PersistentVolumeFilesystem
+
+	return &corev1.PersistentVolume{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolume"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: input.PVName,
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: size},
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{pvAccessMode(volume)},
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			StorageClassName:              input.StorageClass,
+			VolumeMode:                    &filesystem,
+			ClaimRef: &corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "PersistentVolumeClaim",
+				Namespace:  input.PVCNamespace,
+				Name:       input.PVCName,
+			},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       LonghornCSIDriver,
+					VolumeHandle: volume.Name,
+					FSType:       input.FSType,
+				},
+			},
+		},
+	}, nil
+}
+
+// NewVolumePVC returns the PersistentVolumeClaim bound to the PV of
+// NewVolumePV
+func NewVolumePVC(volume *Volume, input *VolumeExposeInput) (*corev1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(volume.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size %q of volume %s: %w", volume.Size, volume.Name, err)
+	}
+
+	input = exposeDefaults(volume, input)
+	filesystem := corev1.PersistentVolumeFilesystem
+
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      input.PVCName,
+			Namespace: input.PVCNamespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{pvAccessMode(volume)},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+			StorageClassName: &input.StorageClass,
+			VolumeMode:       &filesystem,
+			VolumeName:       input.PVName,
+		},
+	}, nil
+}
+
+// ExposeVolume creates a PV for a Longhorn volume and a PVC bound to it. If
+// the PVC cannot be created, the PV is removed again.
+func (c *Client) ExposeVolume(
+	ctx context.Context,
+	volume *Volume,
+	input *VolumeExposeInput,
+) (*corev1.PersistentVolume, *corev1.PersistentVolumeClaim, error) {
+	if c.crdClient == nil || c.crdClient.kubeClient == nil {
+		return nil, nil, ErrKubernetesRequired
+	}
+	if input.PVCName == "" || input.PVCNamespace == "" {
+		return nil, nil, fmt.Errorf("PVC name and namespace are required")
+	}
+	if volume.KubernetesStatus.PVName != "" {
+		return nil, nil, fmt.Errorf("volume %s is already exposed as PV %s",
+			volume.Name, volume.KubernetesStatus.PVName)
+	}
+
+	pv, err := NewVolumePV(volume, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	pvc, err := NewVolumePVC(volume, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	debugLog("Creating PV %s and PVC %s/%s for volume %s",
+		pv.Name, pvc.Namespace, pvc.Name, volume.Name)
+
+	kubeClient := c.crdClient.kubeClient
+	createdPV, err := kubeClient.CoreV1().PersistentVolumes().
+		Create(ctx, pv, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create PV %s: %w", pv.Name, err)
+	}
+
+	createdPVC, err := kubeClient.CoreV1().PersistentVolumeClaims(pvc.Namespace).
+		Create(ctx, pvc, metav1.CreateOptions{})
+	if err != nil {
+		cleanupErr := kubeClient.CoreV1().PersistentVolumes().
+			Delete(ctx, pv.Name, metav1.DeleteOptions{})
+		if cleanupErr != nil {
+			return nil, nil, fmt.Errorf(
+				"failed to create PVC %s/%s: %w (PV %s was left behind: %v)",
+				pvc.Namespace, pvc.Name, err, pv.Name, cleanupErr)
+		}
+		return nil, nil, fmt.Errorf("failed to create PVC %s/%s: %w", pvc.Namespace, pvc.Name, err)
+	}
+
+	return createdPV, createdPVC, nil
+}
+
+// exposeDefaults returns a copy of the input with defaults applied
+func exposeDefaults(volume *Volume, input *VolumeExposeInput) *VolumeExposeInput {
+	result := *input
+	if result.PVName == "" {
+		result.PVName = volume.Name
+	}
+	if result.StorageClass == "" {
+		result.StorageClass = DefaultStaticStorageClass
+	}
+	if result.FSType == "" {
+		result.FSType = DefaultFSType
+	}
+	return &result
+}
+
+// pvAccessMode maps a Longhorn access mode to the Kubernetes one
+func pvAccessMode(volume *Volume) corev1.PersistentVolumeAccessMode {
+	if volume.AccessMode == "rwx" {
+		return corev1.ReadWriteMany
+	}
+	return corev1.ReadWriteOnce
+}
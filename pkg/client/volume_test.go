@@ -0,0 +1,332 @@
+// pkg/client/volume_test.go
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testAPIVolume = `{
+	"name": "vol-1",
+	"size": "10737418240",
+	"numberOfReplicas": 2,
+	"state": "attached",
+	"robustness": "healthy",
+	"replicas": [
+		{"name": "vol-1-r-1", "hostId": "node-1", "diskID": "disk-1", "mode": "RW", "running": true},
+		{"name": "vol-1-r-2", "hostId": "node-2", "diskID": "disk-2", "mode": "WO", "running": true}
+	],
+	"controllers": [{"actualSize": "1048576"}]
+}`
+
+// apiRequest is a request received by a fake Longhorn manager API
+type apiRequest struct {
+	method string
+	uri    string
+	body   map[string]interface{}
+}
+
+// fakeAPI is a Longhorn manager API that answers every request with a fixed
+// status and body and records the requests. Requests for the failAction
+// action are rejected.
+type fakeAPI struct {
+	mu         sync.Mutex
+	requests   []apiRequest
+	failAction string
+}
+
+func newFakeAPI(t *testing.T, status int, body string) (*fakeAPI, *Client) {
+	api := &fakeAPI{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := apiRequest{method: r.Method, uri: r.URL.RequestURI()}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &request.body); err != nil {
+				t.Errorf("invalid request body %q: %v", data, err)
+			}
+		}
+		api.mu.Lock()
+		api.requests = append(api.requests, request)
+		fail := api.failAction != "" && r.URL.Query().Get("action") == api.failAction
+		api.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"message": "rejected"}`)
+			return
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(&Config{Endpoint: server.URL, Retry: &RetryPolicy{}})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return api, c
+}
+
+// uris returns the method and URI of every request
+func (a *fakeAPI) uris() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	uris := make([]string, len(a.requests))
+	for i, r := range a.requests {
+		uris[i] = r.method + " " + r.uri
+	}
+	return uris
+}
+
+func (a *fakeAPI) body(i int) map[string]interface{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.requests[i].body
+}
+
+func checkURIs(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
+
+func checkTestVolume(t *testing.T, volume *Volume) {
+	t.Helper()
+	if volume.Name != "vol-1" || volume.NumberOfReplicas != 2 || volume.ActualSize != 1048576 {
+		t.Errorf("volume = %+v", volume)
+	}
+	if len(volume.Replicas) != 2 {
+		t.Fatalf("replicas = %+v, want 2", volume.Replicas)
+	}
+	replica := volume.Replicas[0]
+	if replica.NodeID != "node-1" || replica.VolumeName != "vol-1" || replica.Mode != "RW" {
+		t.Errorf("replica = %+v", replica)
+	}
+}
+
+func TestVolumeClientList(t *testing.T) {
+	api, c := newFakeAPI(t, http.StatusOK, `{"data": [`+testAPIVolume+`]}`)
+
+	volumes, err := c.Volumes().List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(volumes) != 1 {
+		t.Fatalf("List() returned %d volumes, want 1", len(volumes))
+	}
+	checkTestVolume(t, &volumes[0])
+	checkURIs(t, api.uris(), "GET /v1/volumes")
+}
+
+func TestVolumeClientGet(t *testing.T) {
+	api, c := newFakeAPI(t, http.StatusOK, testAPIVolume)
+
+	volume, err := c.Volumes().Get(context.Background(), "vol-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	checkTestVolume(t, volume)
+	checkURIs(t, api.uris(), "GET /v1/volumes/vol-1")
+}
+
+func TestVolumeClientGetNotFound(t *testing.T) {
+	_, c := newFakeAPI(t, http.StatusNotFound, `{"message": "not found"}`)
+
+	_, err := c.Volumes().Get(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestVolumeClientCreate(t *testing.T) {
+	api, c := newFakeAPI(t, http.StatusOK, testAPIVolume)
+
+	volume, err := c.Volumes().Create(context.Background(), &VolumeCreateInput{
+		Name:             "vol-1",
+		Size:             "10737418240",
+		NumberOfReplicas: 2,
+		AccessMode:       "rwo",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	checkTestVolume(t, volume)
+	checkURIs(t, api.uris(), "POST /v1/volumes")
+
+	body := api.body(0)
+	if body["name"] != "vol-1" || body["size"] != "10737418240" || body["numberOfReplicas"] != 2.0 {
+		t.Errorf("request body = %v", body)
+	}
+}
+
+func TestVolumeClientUpdate(t *testing.T) {
+	api, c := newFakeAPI(t, http.StatusOK, testAPIVolume)
+	replicas := 3
+
+	_, err := c.Volumes().Update(context.Background(), "vol-1", &VolumeUpdateInput{
+		NumberOfReplicas: &replicas,
+		DataLocality:     "best-effort",
+		AccessMode:       "rwx",
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	checkURIs(t, api.uris(),
+		"POST /v1/volumes/vol-1?action=updateReplicaCount",
+		"POST /v1/volumes/vol-1?action=updateDataLocality",
+		"POST /v1/volumes/vol-1?action=updateAccessMode",
+		"GET /v1/volumes/vol-1",
+	)
+	if got := api.body(0)["replicaCount"]; got != 3.0 {
+		t.Errorf("replicaCount = %v, want 3", got)
+	}
+	if got := api.body(1)["dataLocality"]; got != "best-effort" {
+		t.Errorf("dataLocality = %v, want best-effort", got)
+	}
+	if got := api.body(2)["accessMode"]; got != "rwx" {
+		t.Errorf("accessMode = %v, want rwx", got)
+	}
+}
+
+func TestVolumeClientUpdateStopsOnError(t *testing.T) {
+	api, c := newFakeAPI(t, http.StatusOK, testAPIVolume)
+	api.failAction = "updateDataLocality"
+	replicas := 3
+
+	_, err := c.Volumes().Update(context.Background(), "vol-1", &VolumeUpdateInput{
+		NumberOfReplicas: &replicas,
+		DataLocality:     "best-effort",
+		AccessMode:       "rwx",
+	})
+	if err == nil || !strings.HasSuffix(err.Error(), "(already applied: updateReplicaCount)") {
+		t.Errorf("Update() error = %v, want the applied actions", err)
+	}
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.Message != "rejected" {
+		t.Errorf("Update() error = %v, want the API error", err)
+	}
+	checkURIs(t, api.uris(),
+		"POST /v1/volumes/vol-1?action=updateReplicaCount",
+		"POST /v1/volumes/vol-1?action=updateDataLocality",
+	)
+}
+
+func TestVolumeClientUpdateValidation(t *testing.T) {
+	zero, three := 0, 3
+
+	tests := []struct {
+		name   string
+		update VolumeUpdateInput
+	}{
+		{name: "replica count", update: VolumeUpdateInput{NumberOfReplicas: &zero}},
+		{
+			name:   "data locality",
+			update: VolumeUpdateInput{NumberOfReplicas: &three, DataLocality: "nearby"},
+		},
+		{
+			name:   "access mode",
+			update: VolumeUpdateInput{NumberOfReplicas: &three, AccessMode: "rwm"},
+		},
+		{name: "labels", update: VolumeUpdateInput{Labels: map[string]string{"team": "a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, c := newFakeAPI(t, http.StatusOK, testAPIVolume)
+
+			_, err := c.Volumes().Update(context.Background(), "vol-1", &tt.update)
+			if err == nil {
+				t.Fatal("Update() succeeded, want error")
+			}
+			checkURIs(t, api.uris())
+		})
+	}
+}
+
+func TestVolumeClientAttachDetach(t *testing.T) {
+	api, c := newFakeAPI(t, http.StatusOK, testAPIVolume)
+
+	_, err := c.Volumes().Attach(context.Background(), "vol-1", &VolumeAttachInput{HostID: "node-1"})
+	if err != nil {
+		t.Fatalf("Attach() error = %v", err)
+	}
+	if err := c.Volumes().Detach(context.Background(), "vol-1"); err != nil {
+		t.Fatalf("Detach() error = %v", err)
+	}
+
+	checkURIs(t, api.uris(),
+		"POST /v1/volumes/vol-1?action=attach",
+		"POST /v1/volumes/vol-1?action=detach",
+	)
+	if got := api.body(0)["hostId"]; got != "node-1" {
+		t.Errorf("hostId = %v, want node-1", got)
+	}
+}
+
+func TestVolumeClientErrorResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantCode    string
+		wantKind    error
+	}{
+		{
+			name:        "API error",
+			status:      http.StatusConflict,
+			body:        `{"type": "error", "status": 409, "code": "Conflict", "message": "volume is busy"}`,
+			wantMessage: "volume is busy",
+			wantCode:    "Conflict",
+			wantKind:    ErrConflict,
+		},
+		{
+			name:        "API error without status",
+			status:      http.StatusServiceUnavailable,
+			body:        `{"message": "manager is restarting"}`,
+			wantMessage: "manager is restarting",
+			wantKind:    ErrUnavailable,
+		},
+		{
+			name:        "plain text",
+			status:      http.StatusForbidden,
+			body:        "access denied\n",
+			wantMessage: "access denied",
+			wantKind:    ErrForbidden,
+		},
+		{
+			name:        "empty body",
+			status:      http.StatusInternalServerError,
+			wantMessage: "unexpected status code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newFakeAPI(t, tt.status, tt.body)
+
+			_, err := c.Volumes().List(context.Background())
+
+			var apiErr *ErrorResponse
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("List() error = %v, want ErrorResponse", err)
+			}
+			if apiErr.Status != tt.status || apiErr.Message != tt.wantMessage || apiErr.Code != tt.wantCode {
+				t.Errorf("error = %+v, want status %d, message %q, code %q",
+					apiErr, tt.status, tt.wantMessage, tt.wantCode)
+			}
+			if kind := KindOf(err); kind != tt.wantKind {
+				t.Errorf("KindOf() = %v, want %v", kind, tt.wantKind)
+			}
+		})
+	}
+}
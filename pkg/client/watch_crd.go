@@ -0,0 +1,44 @@
+// pkg/client/watch_crd.go
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrWatchNotSupported is returned by clients that can only poll for changes
+var ErrWatchNotSupported = errors.New("watch not supported by this client")
+
+// Watch calls callback with all volumes, including their replicas, whenever a
+// volume or replica changes. It blocks until ctx is cancelled.
+func (c *crdVolumeClient) Watch(ctx context.Context, callback func([]Volume)) error {
+	debugLog("Watching Longhorn volumes via CRD")
+
+	resources := newResourceCache(c.crdClient, volumeGVR, replicaGVR)
+
+	// Bursts of changes are collapsed into a single callback
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	if err := resources.OnChange(notify); err != nil {
+		return err
+	}
+
+	if err := resources.Start(ctx); err != nil {
+		return err
+	}
+	notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+			callback(resources.Volumes())
+		}
+	}
+}
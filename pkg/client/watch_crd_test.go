@@ -0,0 +1,87 @@
+// pkg/client/watch_crd_test.go
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func testVolume(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "longhorn.io/v1beta2",
+		"kind":       "Volume",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "longhorn-system",
+		},
+	}}
+}
+
+func TestVolumeWatchCollapsesBursts(t *testing.T) {
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			volumeGVR:  "VolumeList",
+			replicaGVR: "ReplicaList",
+		},
+		testVolume("vol-1"),
+	)
+	volumes := &crdVolumeClient{crdClient: &LonghornCRDClient{
+		dynamicClient: dynamicClient,
+		watchClient:   dynamicClient,
+		namespace:     "longhorn-system",
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first callback blocks while a burst of changes arrives
+	calls := make(chan []Volume, 10)
+	release := make(chan struct{})
+	go volumes.Watch(ctx, func(v []Volume) {
+		calls <- v
+		<-release
+	})
+
+	select {
+	case initial := <-calls:
+		if len(initial) != 1 {
+			t.Fatalf("initial callback with %d volumes, want 1", len(initial))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no initial callback")
+	}
+
+	resource := dynamicClient.Resource(volumeGVR).Namespace("longhorn-system")
+	for i := 2; i <= 6; i++ {
+		_, err := resource.Create(ctx, testVolume(fmt.Sprintf("vol-%d", i)), metav1.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(500 * time.Millisecond)
+	close(release)
+
+	select {
+	case burst := <-calls:
+		if len(burst) != 6 {
+			t.Errorf("callback after the burst with %d volumes, want 6", len(burst))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no callback after the burst")
+	}
+
+	select {
+	case extra := <-calls:
+		t.Errorf("burst was not collapsed: extra callback with %d volumes", len(extra))
+	case <-time.After(200 * time.Millisecond):
+	}
+}
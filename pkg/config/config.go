@@ -22,6 +22,7 @@ type Context struct {
 	Endpoint  string `yaml:"endpoint"`
 	Namespace string `yaml:"namespace"`
 	Auth      Auth   `yaml:"auth"`
+	Retry     *Retry `yaml:"retry,omitempty"`
 }
 
 // Auth represents authentication configuration
@@ -32,6 +33,14 @@ type Auth struct {
 	Context string `yaml:"context,omitempty"` // Kubernetes context to use
 }
 
+// Retry configures how requests that failed with a transient error are
+// retried. Unset fields keep their defaults.
+type Retry struct {
+	MaxRetries     *int   `yaml:"max-retries,omitempty"`     // 0 disables retries
+	InitialBackoff string `yaml:"initial-backoff,omitempty"` // e.g. 500ms
+	MaxBackoff     string `yaml:"max-backoff,omitempty"`     // e.g. 10s
+}
+
 // Defaults represents default settings
 type Defaults struct {
 	OutputFormat string `yaml:"output-format"`
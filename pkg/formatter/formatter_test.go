@@ -191,6 +191,8 @@ func TestFormatStatus(t *testing.T) {
 		{"Error", true},
 		{"Pending", true},
 		{"Unknown", true},
+		{"Degraded", true},
+		{"Faulted", true},
 		{"Ready", false},
 	}
 
@@ -208,4 +210,13 @@ func TestFormatStatus(t *testing.T) {
 			}
 		}
 	}
+
+	// Negative statuses must not be coloured like their positive counterparts
+	for _, pair := range [][2]string{{"NotReady", "Ready"}, {"Unhealthy", "Healthy"}} {
+		negative := strings.TrimSuffix(FormatStatus(pair[0], true), pair[0]+"\033[0m")
+		positive := strings.TrimSuffix(FormatStatus(pair[1], true), pair[1]+"\033[0m")
+		if negative == positive {
+			t.Errorf("Expected %s and %s to use different colors", pair[0], pair[1])
+		}
+	}
 }
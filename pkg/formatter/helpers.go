@@ -118,22 +118,28 @@ func FormatStatus(status string, useColor bool) string {
 	)
 
 	// Map status to colors
+	// Negative statuses are matched first so that "unhealthy" and "NotReady"
+	// are not coloured as "healthy" and "Ready"
 	statusLower := strings.ToLower(status)
 	switch {
+	case strings.Contains(statusLower, "error"),
+		strings.Contains(statusLower, "failed"),
+		strings.Contains(statusLower, "faulted"),
+		strings.Contains(statusLower, "unhealthy"),
+		strings.Contains(statusLower, "notready"):
+		return colorRed + status + colorReset
+
 	case strings.Contains(statusLower, "ready"),
 		strings.Contains(statusLower, "running"),
 		strings.Contains(statusLower, "active"),
 		strings.Contains(statusLower, "healthy"):
 		return colorGreen + status + colorReset
 
-	case strings.Contains(statusLower, "error"),
-		strings.Contains(statusLower, "failed"),
-		strings.Contains(statusLower, "unhealthy"):
-		return colorRed + status + colorReset
-
 	case strings.Contains(statusLower, "pending"),
 		strings.Contains(statusLower, "creating"),
-		strings.Contains(statusLower, "updating"):
+		strings.Contains(statusLower, "updating"),
+		strings.Contains(statusLower, "degraded"),
+		strings.Contains(statusLower, "rebuilding"):
 		return colorYellow + status + colorReset
 
 	case strings.Contains(statusLower, "unknown"),
@@ -0,0 +1,382 @@
+// pkg/health/checks.go
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// Longhorn settings used by the checks
+const (
+	SettingDefaultEngineImage          = "default-engine-image"
+	SettingDefaultInstanceManagerImage = "default-instance-manager-image"
+)
+
+// DefaultChecks returns all checks in the order they are reported
+func DefaultChecks() []Check {
+	return []Check{
+		{
+			Name:        "node-ready",
+			Description: "All Longhorn nodes are ready",
+			Run:         checkNodeReady,
+		},
+		{
+			Name:        "node-schedulable",
+			Description: "All Longhorn nodes accept new replicas",
+			Run:         checkNodeSchedulable,
+		},
+		{
+			Name:        "disks",
+			Description: "All disks are ready and schedulable",
+			Run:         checkDisks,
+		},
+		{
+			Name:        "volumes",
+			Description: "No volume is degraded or faulted",
+			Run:         checkVolumes,
+		},
+		{
+			Name:        "backup-target",
+			Description: "The backup target is configured and available",
+			Run:         checkBackupTarget,
+		},
+		{
+			Name:        "engine-images",
+			Description: "Engine images in use are deployed on all ready nodes",
+			Run:         checkEngineImages,
+		},
+		{
+			Name:        "orphaned-replicas",
+			Description: "Every replica belongs to an existing volume",
+			Run:         checkOrphanedReplicas,
+		},
+		{
+			Name:        "instance-managers",
+			Description: "Instance managers are running and up to date",
+			Run:         checkInstanceManagers,
+		},
+	}
+}
+
+// NodeStatus returns Ready, NotReady or Unknown from the node conditions
+func NodeStatus(node client.Node) string {
+	if readyCondition, exists := node.Conditions["Ready"]; exists {
+		if readyCondition.Status == "True" {
+			return "Ready"
+		}
+		return "NotReady"
+	}
+	return "Unknown"
+}
+
+// VolumeState returns the volume state, derived from the conditions if the
+// state is not reported
+func VolumeState(volume client.Volume) string {
+	if volume.State != "" {
+		return volume.State
+	}
+	if scheduledCondition, exists := volume.Conditions["Scheduled"]; exists {
+		if scheduledCondition.Status == "True" {
+			return "Attached"
+		}
+	}
+	return "Detached"
+}
+
+func checkNodeReady(src Source) (Result, error) {
+	nodes, err := src.Nodes()
+	if err != nil {
+		return Result{}, err
+	}
+
+	var f findings
+	for _, node := range sortedNodes(nodes) {
+		if status := NodeStatus(node); status != "Ready" {
+			f.fail("node %s is %s%s", node.Name, status, conditionReason(node.Conditions["Ready"]))
+		}
+	}
+
+	return f.result(
+		fmt.Sprintf("%d node(s) ready", len(nodes)),
+		"Check the kubelet and the longhorn-manager pod on the affected nodes",
+	), nil
+}
+
+func checkNodeSchedulable(src Source) (Result, error) {
+	nodes, err := src.Nodes()
+	if err != nil {
+		return Result{}, err
+	}
+
+	var f findings
+	for _, node := range sortedNodes(nodes) {
+		if !node.AllowScheduling {
+			f.warn("scheduling is disabled on node %s", node.Name)
+			continue
+		}
+		if cond, ok := node.Conditions["Schedulable"]; ok && cond.Status != "True" {
+			f.warn("node %s is not schedulable%s", node.Name, conditionReason(cond))
+		}
+	}
+
+	return f.result(
+		fmt.Sprintf("%d node(s) schedulable", len(nodes)),
+		"Re-enable scheduling with 'lhcli node scheduling enable <node>' once "+
+			"maintenance is finished, or uncordon the Kubernetes node",
+	), nil
+}
+
+func checkDisks(src Source) (Result, error) {
+	nodes, err := src.Nodes()
+	if err != nil {
+		return Result{}, err
+	}
+
+	var f findings
+	disks := 0
+	for _, node := range sortedNodes(nodes) {
+		for _, name := range sortedKeys(node.Disks) {
+			disk := node.Disks[name]
+			disks++
+
+			if cond, ok := disk.Conditions["Ready"]; ok && cond.Status != "True" {
+				f.fail("disk %s on node %s is not ready%s", name, node.Name, conditionReason(cond))
+				continue
+			}
+			// Disks with scheduling disabled on purpose are not reported
+			if !disk.AllowScheduling {
+				continue
+			}
+			if cond, ok := disk.Conditions["Schedulable"]; ok && cond.Status != "True" {
+				f.warn("disk %s on node %s is not schedulable%s",
+					name, node.Name, conditionReason(cond))
+			}
+		}
+	}
+
+	return f.result(
+		fmt.Sprintf("%d disk(s) ready", disks),
+		"Check that the disk is mounted and has free space; 'lhcli capacity' "+
+			"shows why a disk cannot accept replicas",
+	), nil
+}
+
+func checkVolumes(src Source) (Result, error) {
+	volumes, err := src.Volumes()
+	if err != nil {
+		return Result{}, err
+	}
+
+	sorted := append([]client.Volume(nil), volumes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var f findings
+	for _, volume := range sorted {
+		state := strings.ToLower(VolumeState(volume))
+		switch strings.ToLower(volume.Robustness) {
+		case "faulted":
+			f.fail("volume %s is faulted (%s)", volume.Name, state)
+		case "degraded":
+			f.warn("volume %s is degraded (%s)", volume.Name, state)
+		}
+	}
+
+	return f.result(
+		fmt.Sprintf("%d volume(s) healthy", len(volumes)),
+		"Inspect the replicas with 'lhcli volume get <volume>'. Degraded volumes "+
+			"rebuild once enough nodes and disks are schedulable; faulted volumes "+
+			"need a replica salvaged or a restore from backup",
+	), nil
+}
+
+func checkBackupTarget(src Source) (Result, error) {
+	target, err := src.BackupTarget()
+	if err != nil {
+		return Result{}, err
+	}
+
+	var f findings
+	switch {
+	case target.BackupTargetURL == "":
+		f.warn("no backup target is configured")
+	case !target.Available:
+		message := target.Message
+		if message == "" {
+			message = "unknown reason"
+		}
+		f.fail("backup target %s is unavailable: %s", target.BackupTargetURL, message)
+	}
+
+	return f.result(
+		fmt.Sprintf("backup target %s is available", target.BackupTargetURL),
+		"Check the backup target URL, the credential secret and network access "+
+			"from the Longhorn nodes to the backup store",
+	), nil
+}
+
+func checkEngineImages(src Source) (Result, error) {
+	images, err := src.EngineImages()
+	if err != nil {
+		return Result{}, err
+	}
+	nodes, err := src.Nodes()
+	if err != nil {
+		return Result{}, err
+	}
+
+	// The default image is only known if the settings are readable
+	defaultImage := ""
+	if settings, err := src.Settings(); err == nil {
+		defaultImage = settings[SettingDefaultEngineImage].Value
+	}
+
+	sort.Slice(images, func(i, j int) bool { return images[i].Name < images[j].Name })
+
+	var f findings
+	for _, image := range images {
+		isDefault := image.Default || (defaultImage != "" && image.Image == defaultImage)
+		// Unused images that are not the default do not need to be deployed
+		if !isDefault && image.RefCount == 0 {
+			continue
+		}
+
+		var missing []string
+		for _, node := range sortedNodes(nodes) {
+			if NodeStatus(node) == "Ready" && !image.NodeDeploymentMap[node.Name] {
+				missing = append(missing, node.Name)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		if isDefault {
+			f.fail("default engine image %s is not deployed on %s",
+				image.Image, strings.Join(missing, ", "))
+		} else {
+			f.warn("engine image %s used by %d volume(s) is not deployed on %s",
+				image.Image, image.RefCount, strings.Join(missing, ", "))
+		}
+	}
+
+	return f.result(
+		fmt.Sprintf("%d engine image(s) deployed", len(images)),
+		"Check the engine-image-ei-* DaemonSet pods on the affected nodes; volumes "+
+			"cannot attach to nodes without their engine image",
+	), nil
+}
+
+func checkOrphanedReplicas(src Source) (Result, error) {
+	replicas, err := src.Replicas()
+	if err != nil {
+		return Result{}, err
+	}
+	volumes, err := src.Volumes()
+	if err != nil {
+		return Result{}, err
+	}
+
+	existing := make(map[string]bool, len(volumes))
+	for _, volume := range volumes {
+		existing[volume.Name] = true
+	}
+
+	sort.Slice(replicas, func(i, j int) bool { return replicas[i].Name < replicas[j].Name })
+
+	var f findings
+	for _, replica := range replicas {
+		if replica.VolumeName != "" && existing[replica.VolumeName] {
+			continue
+		}
+		volume := replica.VolumeName
+		if volume == "" {
+			volume = "<none>"
+		}
+		f.warn("replica %s on node %s belongs to missing volume %s",
+			replica.Name, replica.NodeID, volume)
+	}
+
+	return f.result(
+		fmt.Sprintf("%d replica(s) belong to existing volumes", len(replicas)),
+		"Review the data with 'lhcli orphan list' and reclaim the space with 'lhcli orphan delete'",
+	), nil
+}
+
+func checkInstanceManagers(src Source) (Result, error) {
+	managers, err := src.InstanceManagers()
+	if err != nil {
+		return Result{}, err
+	}
+	nodes, err := src.Nodes()
+	if err != nil {
+		return Result{}, err
+	}
+
+	ready := make(map[string]bool, len(nodes))
+	known := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		known[node.Name] = true
+		ready[node.Name] = NodeStatus(node) == "Ready"
+	}
+
+	currentImage := ""
+	if settings, err := src.Settings(); err == nil {
+		currentImage = settings[SettingDefaultInstanceManagerImage].Value
+	}
+
+	sort.Slice(managers, func(i, j int) bool { return managers[i].Name < managers[j].Name })
+
+	var f findings
+	for _, manager := range managers {
+		state := strings.ToLower(manager.CurrentState)
+		switch {
+		case !known[manager.NodeID]:
+			f.warn("instance manager %s is on node %s, which is not a Longhorn node",
+				manager.Name, manager.NodeID)
+		case state == "error" || state == "unknown":
+			f.fail("instance manager %s on node %s is in state %s",
+				manager.Name, manager.NodeID, manager.CurrentState)
+		case state != "running" && ready[manager.NodeID]:
+			f.warn("instance manager %s on node %s is %s",
+				manager.Name, manager.NodeID, manager.CurrentState)
+		case currentImage != "" && manager.Image != currentImage && len(manager.Instances) == 0:
+			f.warn("instance manager %s on node %s runs outdated image %s and has no instances",
+				manager.Name, manager.NodeID, manager.Image)
+		}
+	}
+
+	return f.result(
+		fmt.Sprintf("%d instance manager(s) running", len(managers)),
+		"Check the instance-manager pods and the longhorn-manager logs; stale "+
+			"instance managers are removed by Longhorn once their instances are gone",
+	), nil
+}
+
+// conditionReason formats the reason of a condition for a message
+func conditionReason(cond client.Status) string {
+	switch {
+	case cond.Message != "":
+		return ": " + cond.Message
+	case cond.Reason != "":
+		return ": " + cond.Reason
+	}
+	return ""
+}
+
+func sortedNodes(nodes []client.Node) []client.Node {
+	sorted := append([]client.Node(nil), nodes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+func sortedKeys(disks map[string]client.Disk) []string {
+	keys := make([]string, 0, len(disks))
+	for k := range disks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,200 @@
+// pkg/health/health.go
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// Check statuses, from best to worst
+const (
+	StatusPass = "PASS"
+	StatusWarn = "WARN"
+	StatusFail = "FAIL"
+)
+
+// Finding is a single problem found by a check
+type Finding struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Check    string    `json:"check"`
+	Status   string    `json:"status"`
+	Message  string    `json:"message"`
+	Hint     string    `json:"hint,omitempty"`
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// Report is the outcome of all checks
+type Report struct {
+	Status   string   `json:"status"`
+	Passed   int      `json:"passed"`
+	Warnings int      `json:"warnings"`
+	Failed   int      `json:"failed"`
+	Results  []Result `json:"results"`
+}
+
+// Source provides the cluster state inspected by the checks
+type Source interface {
+	Nodes() ([]client.Node, error)
+	Volumes() ([]client.Volume, error)
+	Replicas() ([]client.Replica, error)
+	Settings() (map[string]client.Setting, error)
+	BackupTarget() (*client.BackupTarget, error)
+	EngineImages() ([]client.EngineImage, error)
+	InstanceManagers() ([]client.InstanceManager, error)
+}
+
+// Check is a single health check. Checks are independent of each other: a
+// check that cannot read its resources reports a warning without affecting
+// the others.
+type Check struct {
+	Name        string
+	Description string
+	Run         func(src Source) (Result, error)
+}
+
+// Run runs the checks against a source
+func Run(src Source, checks []Check) *Report {
+	report := &Report{Status: StatusPass}
+
+	for _, check := range checks {
+		result, err := check.Run(src)
+		if err != nil {
+			result = Result{
+				Status:  StatusWarn,
+				Message: fmt.Sprintf("check could not run: %v", err),
+				Hint:    "Make sure the Longhorn resources are readable with the current context",
+			}
+		}
+		result.Check = check.Name
+
+		switch result.Status {
+		case StatusFail:
+			report.Failed++
+		case StatusWarn:
+			report.Warnings++
+		default:
+			report.Passed++
+		}
+		report.Status = worst(report.Status, result.Status)
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// worst returns the more severe of two statuses
+func worst(a, b string) string {
+	if a == StatusFail || b == StatusFail {
+		return StatusFail
+	}
+	if a == StatusWarn || b == StatusWarn {
+		return StatusWarn
+	}
+	return StatusPass
+}
+
+// findings collects the problems found by a check
+type findings []Finding
+
+func (f *findings) fail(format string, args ...interface{}) {
+	*f = append(*f, Finding{Status: StatusFail, Message: fmt.Sprintf(format, args...)})
+}
+
+func (f *findings) warn(format string, args ...interface{}) {
+	*f = append(*f, Finding{Status: StatusWarn, Message: fmt.Sprintf(format, args...)})
+}
+
+// result summarizes the findings. Without findings the check passes with the
+// given message, otherwise the hint explains how to fix the problems.
+func (f findings) result(passMessage, hint string) Result {
+	if len(f) == 0 {
+		return Result{Status: StatusPass, Message: passMessage}
+	}
+
+	status := StatusPass
+	for _, finding := range f {
+		status = worst(status, finding.Status)
+	}
+
+	message := f[0].Message
+	if len(f) > 1 {
+		message = fmt.Sprintf("%s (and %d more)", message, len(f)-1)
+	}
+
+	return Result{
+		Status:   status,
+		Message:  message,
+		Hint:     hint,
+		Findings: f,
+	}
+}
+
+// clientSource reads the cluster state through a client. Resources used by
+// several checks are read once.
+type clientSource struct {
+	ctx    context.Context
+	client *client.Client
+
+	nodes        []client.Node
+	nodesErr     error
+	nodesRead    bool
+	volumes      []client.Volume
+	volumesErr   error
+	volumesRead  bool
+	settings     map[string]client.Setting
+	settingsErr  error
+	settingsRead bool
+}
+
+// NewClientSource creates a source that reads from the cluster. Reads are
+// cancelled with ctx.
+func NewClientSource(ctx context.Context, c *client.Client) Source {
+	return &clientSource{ctx: ctx, client: c}
+}
+
+func (s *clientSource) Nodes() ([]client.Node, error) {
+	if !s.nodesRead {
+		s.nodes, s.nodesErr = s.client.Nodes().List(s.ctx)
+		s.nodesRead = true
+	}
+	return s.nodes, s.nodesErr
+}
+
+func (s *clientSource) Volumes() ([]client.Volume, error) {
+	if !s.volumesRead {
+		s.volumes, s.volumesErr = s.client.Volumes().List(s.ctx)
+		s.volumesRead = true
+	}
+	return s.volumes, s.volumesErr
+}
+
+func (s *clientSource) Replicas() ([]client.Replica, error) {
+	return s.client.Replicas().List(s.ctx)
+}
+
+func (s *clientSource) Settings() (map[string]client.Setting, error) {
+	if !s.settingsRead {
+		s.settings, s.settingsErr = s.client.Settings().List(s.ctx)
+		s.settingsRead = true
+	}
+	return s.settings, s.settingsErr
+}
+
+func (s *clientSource) BackupTarget() (*client.BackupTarget, error) {
+	return s.client.Backups().GetTarget(s.ctx)
+}
+
+func (s *clientSource) EngineImages() ([]client.EngineImage, error) {
+	return s.client.EngineImages().List(s.ctx)
+}
+
+func (s *clientSource) InstanceManagers() ([]client.InstanceManager, error) {
+	return s.client.InstanceManagers().List(s.ctx)
+}
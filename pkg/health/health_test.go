@@ -0,0 +1,158 @@
+// pkg/health/health_test.go
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// fakeSource serves fixed resources; a nil target makes BackupTarget fail
+type fakeSource struct {
+	nodes    []client.Node
+	volumes  []client.Volume
+	replicas []client.Replica
+	settings map[string]client.Setting
+	target   *client.BackupTarget
+	images   []client.EngineImage
+	managers []client.InstanceManager
+}
+
+func (s *fakeSource) Nodes() ([]client.Node, error)                       { return s.nodes, nil }
+func (s *fakeSource) Volumes() ([]client.Volume, error)                   { return s.volumes, nil }
+func (s *fakeSource) Replicas() ([]client.Replica, error)                 { return s.replicas, nil }
+func (s *fakeSource) Settings() (map[string]client.Setting, error)        { return s.settings, nil }
+func (s *fakeSource) EngineImages() ([]client.EngineImage, error)         { return s.images, nil }
+func (s *fakeSource) InstanceManagers() ([]client.InstanceManager, error) { return s.managers, nil }
+
+func (s *fakeSource) BackupTarget() (*client.BackupTarget, error) {
+	if s.target == nil {
+		return nil, errors.New("not implemented")
+	}
+	return s.target, nil
+}
+
+func readyNode(name string) client.Node {
+	return client.Node{
+		Name:            name,
+		AllowScheduling: true,
+		Conditions: map[string]client.Status{
+			"Ready":       {Status: "True"},
+			"Schedulable": {Status: "True"},
+		},
+		Disks: map[string]client.Disk{
+			"disk-1": {
+				AllowScheduling: true,
+				Conditions: map[string]client.Status{
+					"Ready":       {Status: "True"},
+					"Schedulable": {Status: "True"},
+				},
+			},
+		},
+	}
+}
+
+func healthySource() *fakeSource {
+	return &fakeSource{
+		nodes:    []client.Node{readyNode("node-1"), readyNode("node-2")},
+		volumes:  []client.Volume{{Name: "vol-1", State: "attached", Robustness: "healthy"}},
+		replicas: []client.Replica{{Name: "vol-1-r-1", VolumeName: "vol-1", NodeID: "node-1"}},
+		settings: map[string]client.Setting{
+			SettingDefaultEngineImage:          {Value: "longhornio/longhorn-engine:v1.7.0"},
+			SettingDefaultInstanceManagerImage: {Value: "longhornio/longhorn-instance-manager:v1.7.0"},
+		},
+		target: &client.BackupTarget{BackupTargetURL: "s3://backups@us-east-1/", Available: true},
+		images: []client.EngineImage{{
+			Name:              "ei-1",
+			Image:             "longhornio/longhorn-engine:v1.7.0",
+			RefCount:          1,
+			NodeDeploymentMap: map[string]bool{"node-1": true, "node-2": true},
+		}},
+		managers: []client.InstanceManager{{
+			Name:         "instance-manager-1",
+			NodeID:       "node-1",
+			Image:        "longhornio/longhorn-instance-manager:v1.7.0",
+			CurrentState: "running",
+		}},
+	}
+}
+
+func TestRunHealthy(t *testing.T) {
+	report := Run(healthySource(), DefaultChecks())
+
+	if report.Status != StatusPass {
+		for _, result := range report.Results {
+			t.Logf("%s: %s %s", result.Check, result.Status, result.Message)
+		}
+		t.Fatalf("status = %s, want %s", report.Status, StatusPass)
+	}
+	if report.Passed != len(DefaultChecks()) {
+		t.Errorf("passed = %d, want %d", report.Passed, len(DefaultChecks()))
+	}
+}
+
+func TestRunProblems(t *testing.T) {
+	src := healthySource()
+
+	notReady := readyNode("node-2")
+	notReady.Conditions["Ready"] = client.Status{Status: "False", Message: "kubelet stopped"}
+	src.nodes[1] = notReady
+
+	src.volumes = append(src.volumes, client.Volume{Name: "vol-2", Robustness: "degraded"})
+	src.replicas = append(src.replicas, client.Replica{Name: "gone-r-1", VolumeName: "gone"})
+	src.images[0].NodeDeploymentMap = map[string]bool{"node-1": true}
+	src.managers = append(src.managers, client.InstanceManager{
+		Name:         "instance-manager-old",
+		NodeID:       "node-1",
+		Image:        "longhornio/longhorn-instance-manager:v1.6.0",
+		CurrentState: "running",
+	})
+	src.target = nil
+
+	report := Run(src, DefaultChecks())
+
+	want := map[string]string{
+		"node-ready":        StatusFail,
+		"node-schedulable":  StatusPass,
+		"disks":             StatusPass,
+		"volumes":           StatusWarn,
+		"backup-target":     StatusWarn, // could not be read
+		"engine-images":     StatusPass, // node-2 is not ready
+		"orphaned-replicas": StatusWarn,
+		"instance-managers": StatusWarn,
+	}
+	for _, result := range report.Results {
+		if result.Status != want[result.Check] {
+			t.Errorf("%s: status = %s (%s), want %s",
+				result.Check, result.Status, result.Message, want[result.Check])
+		}
+		if result.Status != StatusPass && result.Hint == "" {
+			t.Errorf("%s: missing hint", result.Check)
+		}
+	}
+
+	if report.Status != StatusFail || report.Failed != 1 || report.Warnings != 4 {
+		t.Errorf("report = %s with %d failed and %d warnings, want FAIL with 1 and 4",
+			report.Status, report.Failed, report.Warnings)
+	}
+}
+
+func TestEngineImageMissingOnReadyNode(t *testing.T) {
+	src := healthySource()
+	src.images[0].NodeDeploymentMap = map[string]bool{"node-1": true}
+
+	report := Run(src, DefaultChecks())
+	for _, result := range report.Results {
+		if result.Check != "engine-images" {
+			continue
+		}
+		// The image is the default engine image
+		if result.Status != StatusFail {
+			t.Errorf("status = %s, want %s", result.Status, StatusFail)
+		}
+		if len(result.Findings) != 1 {
+			t.Errorf("findings = %v, want 1", result.Findings)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+// pkg/metrics/metrics.go
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// Prefix of all exported metrics, chosen to not collide with the metrics of
+// the Longhorn manager
+const Prefix = "lhcli_"
+
+// Known values of the volume robustness and state, exported as 0 when they
+// do not apply so that alerts can compare against them
+var (
+	robustnessValues = []string{"healthy", "degraded", "faulted", "unknown"}
+	stateValues      = []string{
+		"creating",
+		"attached",
+		"detached",
+		"attaching",
+		"detaching",
+		"deleting",
+	}
+)
+
+// Snapshot is the cluster state the metrics are derived from
+type Snapshot struct {
+	Volumes []client.Volume
+	Nodes   []client.Node
+	Backups []client.Backup
+	Time    time.Time
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	labels []label
+	value  float64
+}
+
+type family struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+func (f *family) add(value float64, labels ...string) {
+	s := sample{value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		s.labels = append(s.labels, label{name: labels[i], value: labels[i+1]})
+	}
+	f.samples = append(f.samples, s)
+}
+
+// Write writes the metrics of a snapshot in the Prometheus text format
+func Write(w io.Writer, s Snapshot) error {
+	families := append(volumeMetrics(s), replicaMetrics(s)...)
+	families = append(families, nodeMetrics(s)...)
+	families = append(families, backupMetrics(s)...)
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		fmt.Fprintf(bw, "# HELP %s%s %s\n", Prefix, f.name, f.help)
+		fmt.Fprintf(bw, "# TYPE %s%s gauge\n", Prefix, f.name)
+		for _, s := range f.samples {
+			bw.WriteString(Prefix + f.name)
+			if len(s.labels) > 0 {
+				pairs := make([]string, len(s.labels))
+				for i, l := range s.labels {
+					pairs[i] = l.name + `="` + labelEscaper.Replace(l.value) + `"`
+				}
+				bw.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+			bw.WriteString(" " + strconv.FormatFloat(s.value, 'f', -1, 64) + "\n")
+		}
+	}
+	return bw.Flush()
+}
+
+// labelEscaper escapes label values as required by the text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func volumeMetrics(s Snapshot) []*family {
+	robustness := &family{
+		name: "volume_robustness",
+		help: "Volume robustness, 1 for the current value of the robustness label.",
+	}
+	state := &family{
+		name: "volume_state",
+		help: "Volume state, 1 for the current value of the state label.",
+	}
+	size := &family{name: "volume_size_bytes", help: "Provisioned size of the volume."}
+	actual := &family{
+		name: "volume_actual_size_bytes",
+		help: "Actual size of the volume data on a replica.",
+	}
+	desired := &family{name: "volume_replicas", help: "Number of replicas requested for the volume."}
+	healthy := &family{
+		name: "volume_healthy_replicas",
+		help: "Number of running replicas of the volume that have not failed.",
+	}
+
+	for _, volume := range s.Volumes {
+		addEnum(robustness, volume.Name, "robustness", volume.Robustness, robustnessValues)
+		addEnum(state, volume.Name, "state", volume.State, stateValues)
+
+		if bytes, err := strconv.ParseInt(volume.Size, 10, 64); err == nil {
+			size.add(float64(bytes), "volume", volume.Name)
+		}
+		actual.add(float64(volume.ActualSize), "volume", volume.Name)
+		desired.add(float64(volume.NumberOfReplicas), "volume", volume.Name)
+
+		healthy.add(float64(HealthyReplicas(volume)), "volume", volume.Name)
+	}
+
+	return []*family{robustness, state, size, actual, desired, healthy}
+}
+
+// addEnum exports one sample per known value, set to 1 for the current one
+func addEnum(f *family, volume, labelName, current string, known []string) {
+	current = strings.ToLower(current)
+	if current == "" {
+		current = "unknown"
+	}
+
+	values := known
+	found := false
+	for _, v := range known {
+		if v == current {
+			found = true
+			break
+		}
+	}
+	if !found {
+		values = append(append([]string{}, known...), current)
+	}
+
+	for _, v := range values {
+		value := 0.0
+		if v == current {
+			value = 1
+		}
+		f.add(value, "volume", volume, labelName, v)
+	}
+}
+
+func replicaMetrics(s Snapshot) []*family {
+	info := &family{
+		name: "replica_info",
+		help: "Placement of each replica, always 1.",
+	}
+	perDisk := &family{
+		name: "disk_replicas",
+		help: "Number of replicas placed on the disk.",
+	}
+
+	// Replicas reference disks by UUID, nodes list them by name
+	diskNames := make(map[string]string)
+	for _, node := range s.Nodes {
+		for name, disk := range node.Disks {
+			diskNames[node.Name+"/"+name] = name
+			if disk.DiskUUID != "" {
+				diskNames[node.Name+"/"+disk.DiskUUID] = name
+			}
+		}
+	}
+
+	counts := make(map[[2]string]int)
+	for _, volume := range s.Volumes {
+		for _, replica := range volume.Replicas {
+			disk := diskNames[replica.NodeID+"/"+replica.DiskID]
+			if disk == "" {
+				disk = replica.DiskID
+			}
+			info.add(1,
+				"volume", volume.Name,
+				"replica", replica.Name,
+				"node", replica.NodeID,
+				"disk", disk,
+				"mode", replica.Mode,
+			)
+			counts[[2]string{replica.NodeID, disk}]++
+		}
+	}
+
+	keys := make([][2]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		perDisk.add(float64(counts[key]), "node", key[0], "disk", key[1])
+	}
+
+	return []*family{info, perDisk}
+}
+
+func nodeMetrics(s Snapshot) []*family {
+	ready := &family{name: "node_ready", help: "Whether the node is ready (1) or not (0)."}
+	schedulable := &family{
+		name: "node_schedulable",
+		help: "Whether replica scheduling is allowed on the node (1) or not (0).",
+	}
+	maximum := &family{name: "disk_storage_maximum_bytes", help: "Total storage of the disk."}
+	available := &family{
+		name: "disk_storage_available_bytes",
+		help: "Storage available on the disk.",
+	}
+	reserved := &family{
+		name: "disk_storage_reserved_bytes",
+		help: "Storage reserved on the disk for other applications.",
+	}
+	scheduled := &family{
+		name: "disk_storage_scheduled_bytes",
+		help: "Storage scheduled for replicas on the disk.",
+	}
+	usage := &family{
+		name: "disk_usage_ratio",
+		help: "Fraction of the disk storage in use, from 0 to 1.",
+	}
+
+	for _, node := range s.Nodes {
+		isReady := 0.0
+		if cond, ok := node.Conditions["Ready"]; ok && cond.Status == "True" {
+			isReady = 1
+		}
+		ready.add(isReady, "node", node.Name)
+		schedulable.add(boolValue(node.AllowScheduling), "node", node.Name)
+
+		names := make([]string, 0, len(node.Disks))
+		for name := range node.Disks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			disk := node.Disks[name]
+			labels := []string{"node", node.Name, "disk", name, "path", disk.Path}
+			maximum.add(float64(disk.StorageMaximum), labels...)
+			available.add(float64(disk.StorageAvailable), labels...)
+			reserved.add(float64(disk.StorageReserved), labels...)
+			scheduled.add(float64(disk.StorageScheduled), labels...)
+			if disk.StorageMaximum > 0 {
+				used := disk.StorageMaximum - disk.StorageAvailable
+				usage.add(float64(used)/float64(disk.StorageMaximum), labels...)
+			}
+		}
+	}
+
+	return []*family{ready, schedulable, maximum, available, reserved, scheduled, usage}
+}
+
+func backupMetrics(s Snapshot) []*family {
+	timestamp := &family{
+		name: "volume_last_backup_timestamp_seconds",
+		help: "Unix time of the last completed backup of the volume.",
+	}
+	age := &family{
+		name: "volume_last_backup_age_seconds",
+		help: "Seconds since the last completed backup of the volume.",
+	}
+
+	last := LastBackupTimes(s)
+	for _, volume := range s.Volumes {
+		t, ok := last[volume.Name]
+		if !ok {
+			continue
+		}
+		timestamp.add(float64(t.Unix()), "volume", volume.Name)
+		age.add(s.Time.Sub(t).Seconds(), "volume", volume.Name)
+	}
+
+	return []*family{timestamp, age}
+}
+
+// LastBackupTimes returns the time of the last completed backup of every
+// volume that has one. Volumes without listed backups fall back to the last
+// backup time in their status.
+func LastBackupTimes(s Snapshot) map[string]time.Time {
+	last := make(map[string]time.Time)
+	for _, backup := range s.Backups {
+		if !strings.EqualFold(backup.State, "Completed") || backup.VolumeName == "" {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, backup.Created)
+		if err != nil {
+			continue
+		}
+		if created.After(last[backup.VolumeName]) {
+			last[backup.VolumeName] = created
+		}
+	}
+
+	for _, volume := range s.Volumes {
+		if _, ok := last[volume.Name]; ok {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, volume.LastBackupAt); err == nil {
+			last[volume.Name] = t
+		}
+	}
+	return last
+}
+
+// HealthyReplicas counts the replicas of a volume that are healthy
+func HealthyReplicas(volume client.Volume) int {
+	count := 0
+	for _, replica := range volume.Replicas {
+		if IsReplicaHealthy(replica) {
+			count++
+		}
+	}
+	return count
+}
+
+// IsReplicaHealthy reports whether a replica is running and has not failed
+func IsReplicaHealthy(replica client.Replica) bool {
+	if replica.FailedAt != "" {
+		return false
+	}
+	// CRD replicas report "running", the HTTP API reports "RW"
+	return strings.EqualFold(replica.Mode, "running") || replica.Mode == "RW"
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+// pkg/metrics/metrics_test.go
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+func TestWrite(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	snapshot := Snapshot{
+		Time: now,
+		Volumes: []client.Volume{
+			{
+				Name:             "vol-1",
+				Size:             "10737418240",
+				ActualSize:       1024,
+				NumberOfReplicas: 2,
+				State:            "attached",
+				Robustness:       "degraded",
+				Replicas: []client.Replica{
+					{Name: "vol-1-r-1", NodeID: "node-1", DiskID: "uuid-1", Mode: "RW"},
+					{Name: "vol-1-r-2", NodeID: "node-2", DiskID: "uuid-2", FailedAt: "yesterday"},
+				},
+			},
+		},
+		Nodes: []client.Node{
+			{
+				Name:            "node-1",
+				AllowScheduling: true,
+				Conditions:      map[string]client.Status{"Ready": {Status: "True"}},
+				Disks: map[string]client.Disk{
+					"disk-1": {
+						Path:             `/var/lib/"longhorn"`,
+						DiskUUID:         "uuid-1",
+						StorageMaximum:   100,
+						StorageAvailable: 25,
+					},
+				},
+			},
+		},
+		Backups: []client.Backup{
+			{VolumeName: "vol-1", State: "Completed", Created: "2024-01-02T10:00:00Z"},
+			{VolumeName: "vol-1", State: "Completed", Created: "2024-01-01T10:00:00Z"},
+			{VolumeName: "vol-1", State: "InProgress", Created: "2024-01-02T11:00:00Z"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, snapshot); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE lhcli_volume_robustness gauge\n",
+		`lhcli_volume_robustness{volume="vol-1",robustness="degraded"} 1` + "\n",
+		`lhcli_volume_robustness{volume="vol-1",robustness="healthy"} 0` + "\n",
+		`lhcli_volume_state{volume="vol-1",state="attached"} 1` + "\n",
+		`lhcli_volume_size_bytes{volume="vol-1"} 10737418240` + "\n",
+		`lhcli_volume_healthy_replicas{volume="vol-1"} 1` + "\n",
+		`lhcli_replica_info{volume="vol-1",replica="vol-1-r-1",node="node-1",disk="disk-1",mode="RW"} 1`,
+		`lhcli_disk_replicas{node="node-2",disk="uuid-2"} 1` + "\n",
+		`lhcli_disk_usage_ratio{node="node-1",disk="disk-1",path="/var/lib/\"longhorn\""} 0.75`,
+		`lhcli_node_ready{node="node-1"} 1` + "\n",
+		`lhcli_volume_last_backup_age_seconds{volume="vol-1"} 7200` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q\n%s", want, out)
+		}
+	}
+}
+
+func TestIsReplicaHealthy(t *testing.T) {
+	tests := []struct {
+		name    string
+		replica client.Replica
+		want    bool
+	}{
+		{name: "running CRD replica", replica: client.Replica{Mode: "running"}, want: true},
+		{name: "RW API replica", replica: client.Replica{Mode: "RW"}, want: true},
+		{name: "rebuilding", replica: client.Replica{Mode: "WO"}},
+		{name: "error", replica: client.Replica{Mode: "ERR"}},
+		{name: "failed", replica: client.Replica{Mode: "RW", FailedAt: "2024-01-01T00:00:00Z"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsReplicaHealthy(tt.replica); got != tt.want {
+				t.Errorf("IsReplicaHealthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	volume := client.Volume{Replicas: []client.Replica{tests[0].replica, tests[2].replica, tests[4].replica}}
+	if got := HealthyReplicas(volume); got != 1 {
+		t.Errorf("HealthyReplicas() = %d, want 1", got)
+	}
+}
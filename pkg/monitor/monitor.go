@@ -1,89 +1,129 @@
 package monitor
 
 import (
-    "context"
-    "fmt"
-    "time"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pascal71/lhcli/pkg/client"
 )
 
 // Monitor interface for resource monitoring
 type Monitor interface {
-    Start(ctx context.Context, interval time.Duration) error
-    Stop()
+	Start(ctx context.Context, interval time.Duration) error
+	Stop()
+}
+
+// Poll calls refresh immediately and then once per interval until ctx is
+// cancelled. Refresh errors are reported but do not stop the loop.
+func Poll(
+	ctx context.Context,
+	interval time.Duration,
+	refresh func(ctx context.Context) error,
+) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := refresh(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Error refreshing: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopper lets Stop cancel a running Start
+type stopper struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (s *stopper) start(ctx context.Context) context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ctx, s.cancel = context.WithCancel(ctx)
+	return ctx
+}
+
+// Stop stops monitoring
+func (s *stopper) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
 }
 
 // VolumeMonitor monitors volumes
 type VolumeMonitor struct {
-    client interface{} // TODO: Use actual client type
+	stopper
+	volumes  client.VolumeInterface
+	onChange func([]client.Volume)
 }
 
-// NewVolumeMonitor creates a new volume monitor
-func NewVolumeMonitor(client interface{}) *VolumeMonitor {
-    return &VolumeMonitor{client: client}
+// NewVolumeMonitor creates a new volume monitor that calls onChange with all
+// volumes whenever they change
+func NewVolumeMonitor(c *client.Client, onChange func([]client.Volume)) *VolumeMonitor {
+	return &VolumeMonitor{volumes: c.Volumes(), onChange: onChange}
 }
 
-// Start starts monitoring volumes
+// Start starts monitoring volumes. Changes are received through a watch when
+// the client supports it, otherwise volumes are polled every interval.
 func (v *VolumeMonitor) Start(ctx context.Context, interval time.Duration) error {
-    ticker := time.NewTicker(interval)
-    defer ticker.Stop()
-    
-    for {
-        select {
-        case <-ctx.Done():
-            return ctx.Err()
-        case <-ticker.C:
-            if err := v.refresh(); err != nil {
-                fmt.Fprintf(os.Stderr, "Error refreshing volumes: %v\n", err)
-            }
-        }
-    }
-}
+	ctx = v.start(ctx)
 
-// Stop stops monitoring
-func (v *VolumeMonitor) Stop() {
-    // TODO: Implement cleanup
+	err := v.volumes.Watch(ctx, v.onChange)
+	if !errors.Is(err, client.ErrWatchNotSupported) {
+		return err
+	}
+
+	return Poll(ctx, interval, v.refresh)
 }
 
-func (v *VolumeMonitor) refresh() error {
-    // TODO: Implement volume refresh logic
-    fmt.Println("Refreshing volumes...")
-    return nil
+func (v *VolumeMonitor) refresh(ctx context.Context) error {
+	volumes, err := v.volumes.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list volumes: %w", err)
+	}
+	v.onChange(volumes)
+	return nil
 }
 
 // NodeMonitor monitors nodes
 type NodeMonitor struct {
-    client interface{} // TODO: Use actual client type
+	stopper
+	nodes    client.NodeInterface
+	onChange func([]client.Node)
 }
 
-// NewNodeMonitor creates a new node monitor
-func NewNodeMonitor(client interface{}) *NodeMonitor {
-    return &NodeMonitor{client: client}
+// NewNodeMonitor creates a new node monitor that calls onChange with all
+// nodes on every refresh
+func NewNodeMonitor(c *client.Client, onChange func([]client.Node)) *NodeMonitor {
+	return &NodeMonitor{nodes: c.Nodes(), onChange: onChange}
 }
 
 // Start starts monitoring nodes
 func (n *NodeMonitor) Start(ctx context.Context, interval time.Duration) error {
-    ticker := time.NewTicker(interval)
-    defer ticker.Stop()
-    
-    for {
-        select {
-        case <-ctx.Done():
-            return ctx.Err()
-        case <-ticker.C:
-            if err := n.refresh(); err != nil {
-                fmt.Fprintf(os.Stderr, "Error refreshing nodes: %v\n", err)
-            }
-        }
-    }
-}
-
-// Stop stops monitoring
-func (n *NodeMonitor) Stop() {
-    // TODO: Implement cleanup
+	return Poll(n.start(ctx), interval, n.refresh)
 }
 
-func (n *NodeMonitor) refresh() error {
-    // TODO: Implement node refresh logic
-    fmt.Println("Refreshing nodes...")
-    return nil
+func (n *NodeMonitor) refresh(ctx context.Context) error {
+	nodes, err := n.nodes.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	n.onChange(nodes)
+	return nil
 }
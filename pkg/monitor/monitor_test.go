@@ -0,0 +1,162 @@
+// pkg/monitor/monitor_test.go
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// fakeVolumes is a volume client that serves List and Watch from functions
+type fakeVolumes struct {
+	client.VolumeInterface
+
+	mu    sync.Mutex
+	lists int
+	list  func() ([]client.Volume, error)
+	watch func(ctx context.Context, callback func([]client.Volume)) error
+}
+
+func (f *fakeVolumes) List(ctx context.Context) ([]client.Volume, error) {
+	f.mu.Lock()
+	f.lists++
+	f.mu.Unlock()
+	return f.list()
+}
+
+func (f *fakeVolumes) Watch(ctx context.Context, callback func([]client.Volume)) error {
+	return f.watch(ctx, callback)
+}
+
+func (f *fakeVolumes) listCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lists
+}
+
+func volumes(names ...string) []client.Volume {
+	result := make([]client.Volume, len(names))
+	for i, name := range names {
+		result[i] = client.Volume{Name: name}
+	}
+	return result
+}
+
+// startMonitor runs a volume monitor until it has reported want changes
+func startMonitor(
+	t *testing.T,
+	source *fakeVolumes,
+	want int,
+) ([][]client.Volume, error) {
+	t.Helper()
+
+	changes := make(chan []client.Volume)
+	m := &VolumeMonitor{volumes: source, onChange: func(v []client.Volume) { changes <- v }}
+
+	done := make(chan error, 1)
+	go func() { done <- m.Start(context.Background(), 10*time.Millisecond) }()
+
+	var got [][]client.Volume
+	for len(got) < want {
+		select {
+		case v := <-changes:
+			got = append(got, v)
+		case err := <-done:
+			return got, err
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %d of %d changes", len(got), want)
+		}
+	}
+
+	m.Stop()
+	for {
+		select {
+		case <-changes:
+		case err := <-done:
+			return got, err
+		case <-time.After(5 * time.Second):
+			t.Fatal("monitor did not stop")
+		}
+	}
+}
+
+func TestVolumeMonitorWatch(t *testing.T) {
+	source := &fakeVolumes{
+		list: func() ([]client.Volume, error) { return volumes("vol-a"), nil },
+		watch: func(ctx context.Context, callback func([]client.Volume)) error {
+			callback(volumes("vol-a"))
+			callback(volumes("vol-a", "vol-b"))
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	got, err := startMonitor(t, source, 2)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Start() error = %v, want context.Canceled", err)
+	}
+	if len(got) != 2 || len(got[1]) != 2 {
+		t.Errorf("changes = %v, want the watched volumes", got)
+	}
+	if lists := source.listCount(); lists != 0 {
+		t.Errorf("volumes listed %d times while watching", lists)
+	}
+}
+
+func TestVolumeMonitorWatchError(t *testing.T) {
+	watchErr := errors.New("forbidden")
+	source := &fakeVolumes{
+		list: func() ([]client.Volume, error) { return volumes("vol-a"), nil },
+		watch: func(ctx context.Context, callback func([]client.Volume)) error {
+			return watchErr
+		},
+	}
+
+	_, err := startMonitor(t, source, 1)
+
+	if !errors.Is(err, watchErr) {
+		t.Errorf("Start() error = %v, want %v", err, watchErr)
+	}
+	if lists := source.listCount(); lists != 0 {
+		t.Errorf("volumes listed %d times after the watch failed", lists)
+	}
+}
+
+func TestVolumeMonitorPollFallback(t *testing.T) {
+	source := &fakeVolumes{
+		watch: func(ctx context.Context, callback func([]client.Volume)) error {
+			return client.ErrWatchNotSupported
+		},
+	}
+	// A failing refresh does not stop polling
+	source.list = func() ([]client.Volume, error) {
+		if source.lists == 2 {
+			return nil, errors.New("unavailable")
+		}
+		return volumes("vol-a"), nil
+	}
+
+	got, err := startMonitor(t, source, 3)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Start() error = %v, want context.Canceled", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("changes = %v, want 3", got)
+	}
+	if lists := source.listCount(); lists < 4 {
+		t.Errorf("volumes listed %d times, want at least 4", lists)
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	err := Poll(context.Background(), 0, func(context.Context) error { return nil })
+	if err == nil {
+		t.Error("Poll() with zero interval succeeded, want error")
+	}
+}
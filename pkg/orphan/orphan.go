@@ -0,0 +1,218 @@
+// pkg/orphan/orphan.go
+package orphan
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+// Kinds of orphaned data
+const (
+	// KindData is data Longhorn reports through an orphan resource
+	KindData = "data"
+	// KindReplica is a replica of a volume that no longer exists
+	KindReplica = "replica"
+	// KindScheduled is disk space scheduled for a replica that no longer
+	// exists. Longhorn releases it by itself, so it cannot be deleted.
+	KindScheduled = "scheduled"
+)
+
+// Item is orphaned data on a disk
+type Item struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Node     string `json:"node"`
+	Disk     string `json:"disk"`
+	DiskPath string `json:"diskPath"`
+	Volume   string `json:"volume,omitempty"`
+	// Size is the space that can be reclaimed, if SizeKnown is set. Longhorn
+	// does not report the size of orphaned data directories.
+	Size      int64 `json:"size"`
+	SizeKnown bool  `json:"sizeKnown"`
+	Deletable bool  `json:"deletable"`
+}
+
+// DiskSummary is the space that can be reclaimed on a disk
+type DiskSummary struct {
+	Node     string `json:"node"`
+	Disk     string `json:"disk"`
+	DiskPath string `json:"diskPath"`
+	Items    int    `json:"items"`
+	Size     int64  `json:"size"`
+	// UnknownSize counts the items whose size is not known
+	UnknownSize int `json:"unknownSize"`
+}
+
+// Report is the orphaned data in the cluster
+type Report struct {
+	Items []Item        `json:"items"`
+	Disks []DiskSummary `json:"disks"`
+	Size  int64         `json:"size"`
+}
+
+// Find collects orphaned data from Longhorn orphan resources and from
+// replicas that belong to deleted volumes. The scheduled replicas of every
+// disk are cross-checked against the existing replicas, which also finds
+// space reserved for replicas that no longer exist.
+func Find(
+	orphans []client.Orphan,
+	nodes []client.Node,
+	replicas []client.Replica,
+	volumes []client.Volume,
+) *Report {
+	volumeExists := make(map[string]bool, len(volumes))
+	for _, volume := range volumes {
+		volumeExists[volume.Name] = true
+	}
+	replicaByName := make(map[string]client.Replica, len(replicas))
+	for _, replica := range replicas {
+		replicaByName[replica.Name] = replica
+	}
+
+	// Disks are referenced by UUID from replicas and by name from orphans
+	type diskRef struct {
+		name string
+		path string
+	}
+	disksByUUID := make(map[string]diskRef)
+	diskPaths := make(map[string]string) // node/disk name -> path
+	for _, node := range nodes {
+		for name, disk := range node.Disks {
+			if disk.DiskUUID != "" {
+				disksByUUID[disk.DiskUUID] = diskRef{name: name, path: disk.Path}
+			}
+			diskPaths[node.Name+"/"+name] = disk.Path
+		}
+	}
+
+	var items []Item
+
+	for _, o := range orphans {
+		item := Item{
+			Kind:      KindData,
+			Name:      o.Name,
+			Node:      o.NodeID,
+			Disk:      o.DiskName,
+			DiskPath:  o.DiskPath,
+			Deletable: true,
+		}
+		if item.DiskPath == "" {
+			item.DiskPath = diskPaths[o.NodeID+"/"+o.DiskName]
+		}
+		items = append(items, item)
+	}
+
+	// Replicas of deleted volumes, with the size scheduled for them
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		for diskName, disk := range node.Disks {
+			for replicaName, size := range disk.ScheduledReplica {
+				replica, exists := replicaByName[replicaName]
+				if exists && volumeExists[replica.VolumeName] {
+					continue
+				}
+				seen[replicaName] = true
+
+				item := Item{
+					Kind:      KindScheduled,
+					Name:      replicaName,
+					Node:      node.Name,
+					Disk:      diskName,
+					DiskPath:  disk.Path,
+					Size:      size,
+					SizeKnown: true,
+				}
+				if exists {
+					item.Kind = KindReplica
+					item.Volume = replica.VolumeName
+					item.Deletable = true
+				}
+				items = append(items, item)
+			}
+		}
+	}
+
+	// Replicas of deleted volumes that are not scheduled on any disk
+	for _, replica := range replicas {
+		if seen[replica.Name] || volumeExists[replica.VolumeName] {
+			continue
+		}
+		disk := disksByUUID[replica.DiskID]
+		item := Item{
+			Kind:      KindReplica,
+			Name:      replica.Name,
+			Node:      replica.NodeID,
+			Disk:      disk.name,
+			DiskPath:  disk.path,
+			Volume:    replica.VolumeName,
+			Deletable: true,
+		}
+		if item.DiskPath == "" {
+			item.DiskPath = replica.DiskPath
+		}
+		if size, err := strconv.ParseInt(replica.VolumeSize, 10, 64); err == nil {
+			item.Size = size
+			item.SizeKnown = true
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Node != b.Node {
+			return a.Node < b.Node
+		}
+		if a.Disk != b.Disk {
+			return a.Disk < b.Disk
+		}
+		return a.Name < b.Name
+	})
+
+	return Summarize(items)
+}
+
+// Summarize computes the reclaimable space per disk of the deletable items
+func Summarize(items []Item) *Report {
+	report := &Report{Items: items, Disks: []DiskSummary{}}
+	if report.Items == nil {
+		report.Items = []Item{}
+	}
+
+	index := make(map[string]int)
+	for _, item := range items {
+		if !item.Deletable {
+			continue
+		}
+		key := item.Node + "/" + item.Disk
+		i, ok := index[key]
+		if !ok {
+			i = len(report.Disks)
+			index[key] = i
+			report.Disks = append(report.Disks, DiskSummary{
+				Node:     item.Node,
+				Disk:     item.Disk,
+				DiskPath: item.DiskPath,
+			})
+		}
+
+		summary := &report.Disks[i]
+		summary.Items++
+		if item.SizeKnown {
+			summary.Size += item.Size
+			report.Size += item.Size
+		} else {
+			summary.UnknownSize++
+		}
+	}
+
+	sort.Slice(report.Disks, func(i, j int) bool {
+		if report.Disks[i].Node != report.Disks[j].Node {
+			return report.Disks[i].Node < report.Disks[j].Node
+		}
+		return report.Disks[i].Disk < report.Disks[j].Disk
+	})
+
+	return report
+}
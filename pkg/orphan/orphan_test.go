@@ -0,0 +1,77 @@
+// pkg/orphan/orphan_test.go
+package orphan
+
+import (
+	"testing"
+
+	"github.com/pascal71/lhcli/pkg/client"
+)
+
+const gi = int64(1024 * 1024 * 1024)
+
+func TestFind(t *testing.T) {
+	nodes := []client.Node{{
+		Name: "node-1",
+		Disks: map[string]client.Disk{
+			"disk-1": {
+				Path:     "/var/lib/longhorn",
+				DiskUUID: "uuid-1",
+				ScheduledReplica: map[string]int64{
+					"live-r-1":    10 * gi,
+					"deleted-r-1": 20 * gi,
+					"missing-r-1": 5 * gi,
+				},
+			},
+		},
+	}}
+	replicas := []client.Replica{
+		{Name: "live-r-1", VolumeName: "live", NodeID: "node-1", DiskID: "uuid-1"},
+		{Name: "deleted-r-1", VolumeName: "deleted", NodeID: "node-1", DiskID: "uuid-1"},
+		{
+			Name:       "unscheduled-r-1",
+			VolumeName: "deleted",
+			NodeID:     "node-1",
+			DiskID:     "uuid-1",
+			VolumeSize: "1073741824",
+		},
+	}
+	volumes := []client.Volume{{Name: "live"}}
+	orphans := []client.Orphan{{
+		Name:     "orphan-1",
+		NodeID:   "node-1",
+		DiskName: "disk-1",
+		DataName: "old-r-1-abcdef",
+	}}
+
+	report := Find(orphans, nodes, replicas, volumes)
+
+	want := map[string]string{
+		"orphan-1":        KindData,
+		"deleted-r-1":     KindReplica,
+		"missing-r-1":     KindScheduled,
+		"unscheduled-r-1": KindReplica,
+	}
+	if len(report.Items) != len(want) {
+		t.Fatalf("items = %+v, want %d", report.Items, len(want))
+	}
+	for _, item := range report.Items {
+		if want[item.Name] != item.Kind {
+			t.Errorf("%s: kind = %s, want %s", item.Name, item.Kind, want[item.Name])
+		}
+		if item.Disk != "disk-1" || item.DiskPath != "/var/lib/longhorn" {
+			t.Errorf("%s: disk = %s (%s), want disk-1", item.Name, item.Disk, item.DiskPath)
+		}
+	}
+
+	// The scheduled space of a missing replica is not reclaimable
+	if len(report.Disks) != 1 {
+		t.Fatalf("disks = %+v, want 1", report.Disks)
+	}
+	disk := report.Disks[0]
+	if disk.Items != 3 || disk.Size != 21*gi || disk.UnknownSize != 1 {
+		t.Errorf("disk summary = %+v, want 3 items, 21 GiB and 1 of unknown size", disk)
+	}
+	if report.Size != 21*gi {
+		t.Errorf("size = %d, want %d", report.Size, 21*gi)
+	}
+}